@@ -2,4 +2,11 @@ resource "materialize_cluster_replica" "example_cluster_replica" {
   name         = "replica"
   cluster_name = "cluster"
   size         = "2xsmall"
+
+  # Wait for the replica to be ready before dependent resources are created
+  wait_for_ready = true
+
+  timeouts {
+    create = "20m"
+  }
 }
\ No newline at end of file
@@ -1,3 +1,15 @@
 resource "materialize_cluster" "example_cluster" {
   name = "cluster"
-}
\ No newline at end of file
+}
+
+resource "materialize_cluster" "example_managed_cluster" {
+  name               = "managed_cluster"
+  size               = "3xsmall"
+  replication_factor = 2
+
+  # Resize by hydrating replicas at the new size before dropping the old ones
+  blue_green_resize = true
+  hydration_timeout = "30m"
+}
+
+# CREATE CLUSTER managed_cluster (SIZE = '3xsmall', REPLICATION FACTOR = 2);
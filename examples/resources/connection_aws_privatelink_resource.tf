@@ -0,0 +1,17 @@
+resource "materialize_connection_aws_privatelink" "example_privatelink_connection" {
+  name               = "privatelink_connection"
+  schema_name        = "schema"
+  service_name       = "com.amazonaws.us-east-1.materialize.example"
+  availability_zones = ["use1-az1", "use1-az2"]
+}
+
+# CREATE CONNECTION schema.privatelink_connection TO AWS PRIVATELINK (
+#     SERVICE NAME 'com.amazonaws.us-east-1.materialize.example',
+#     AVAILABILITY ZONES ('use1-az1', 'use1-az2')
+# );
+
+# Allow the Materialize principal on the endpoint service
+resource "aws_vpc_endpoint_service_allowed_principal" "example_privatelink_principal" {
+  vpc_endpoint_service_id = "vpce-svc-0e123abc123198abc"
+  principal_arn           = materialize_connection_aws_privatelink.example_privatelink_connection.principal
+}
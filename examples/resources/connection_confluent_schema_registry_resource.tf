@@ -0,0 +1,13 @@
+resource "materialize_connection_confluent_schema_registry" "example_csr_connection" {
+  name        = "csr_connection"
+  schema_name = "schema"
+  url         = "https://rp-f00000bar.data.vectorized.cloud:30993"
+  username    = "example"
+  password    = "schema.csr_password"
+}
+
+# CREATE CONNECTION schema.csr_connection TO CONFLUENT SCHEMA REGISTRY (
+#     URL 'https://rp-f00000bar.data.vectorized.cloud:30993',
+#     USERNAME = 'example',
+#     PASSWORD = SECRET schema.csr_password
+# );
@@ -0,0 +1,17 @@
+resource "materialize_connection_kafka" "example_kafka_connection" {
+  name            = "kafka_connection"
+  schema_name     = "schema"
+  kafka_brokers   = ["b-1.hostname-1:9096", "b-2.hostname-2:9096"]
+  progress_topic  = "example"
+  sasl_mechanisms = "SCRAM-SHA-256"
+  sasl_username   = "user"
+  sasl_password   = "schema.kafka_password"
+}
+
+# CREATE CONNECTION schema.kafka_connection TO KAFKA (
+#     BROKERS ('b-1.hostname-1:9096', 'b-2.hostname-2:9096'),
+#     PROGRESS TOPIC 'example',
+#     SASL MECHANISMS = 'SCRAM-SHA-256',
+#     SASL USERNAME = 'user',
+#     SASL PASSWORD = SECRET schema.kafka_password
+# );
@@ -0,0 +1,19 @@
+resource "materialize_connection_postgres" "example_postgres_connection" {
+  name        = "pg_connection"
+  schema_name = "schema"
+  host        = "instance.foo000.us-west-1.rds.amazonaws.com"
+  port        = 5432
+  user        = "example"
+  password    = "schema.pg_password"
+  ssl_mode    = "require"
+  database    = "postgres"
+}
+
+# CREATE CONNECTION schema.pg_connection TO POSTGRES (
+#     HOST 'instance.foo000.us-west-1.rds.amazonaws.com',
+#     PORT 5432,
+#     USER 'example',
+#     PASSWORD SECRET schema.pg_password,
+#     SSL MODE 'require',
+#     DATABASE 'postgres'
+# );
@@ -0,0 +1,21 @@
+resource "materialize_connection_ssh_tunnel" "example_ssh_connection" {
+  name        = "ssh_connection"
+  schema_name = "schema"
+  host        = "example.com"
+  port        = 22
+  user        = "example"
+}
+
+# CREATE CONNECTION schema.ssh_connection TO SSH TUNNEL (
+#     HOST 'example.com',
+#     USER 'example',
+#     PORT 22
+# );
+
+# The generated public keys can be added to the bastion's authorized_keys
+output "ssh_public_keys" {
+  value = [
+    materialize_connection_ssh_tunnel.example_ssh_connection.public_key_1,
+    materialize_connection_ssh_tunnel.example_ssh_connection.public_key_2,
+  ]
+}
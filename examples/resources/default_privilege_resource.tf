@@ -0,0 +1,10 @@
+resource "materialize_default_privilege" "example_default_privilege" {
+  target_role_name = "engineer"
+  grantee_name     = "analyst"
+  object_type      = "TABLE"
+  privilege        = "SELECT"
+  database_name    = "materialize"
+  schema_name      = "public"
+}
+
+# ALTER DEFAULT PRIVILEGES FOR ROLE engineer IN SCHEMA materialize.public GRANT SELECT ON TABLES TO analyst;
@@ -0,0 +1,54 @@
+resource "materialize_database_grant" "example_database_grant" {
+  role_name     = "role"
+  privilege     = "CREATE"
+  database_name = "database"
+}
+
+# GRANT CREATE ON DATABASE database TO role;
+
+resource "materialize_schema_grant" "example_schema_grant" {
+  role_name     = "role"
+  privilege     = "USAGE"
+  schema_name   = "schema"
+  database_name = "database"
+}
+
+# GRANT USAGE ON SCHEMA database.schema TO role;
+
+resource "materialize_cluster_grant" "example_cluster_grant" {
+  role_name    = "role"
+  privilege    = "USAGE"
+  cluster_name = "cluster"
+}
+
+# GRANT USAGE ON CLUSTER cluster TO role;
+
+resource "materialize_table_grant" "example_table_grant" {
+  role_name     = "role"
+  privilege     = "INSERT"
+  table_name    = "table"
+  schema_name   = "schema"
+  database_name = "database"
+}
+
+# GRANT INSERT ON TABLE database.schema.table TO role;
+
+resource "materialize_view_grant" "example_view_grant" {
+  role_name     = "role"
+  privilege     = "SELECT"
+  view_name     = "view"
+  schema_name   = "schema"
+  database_name = "database"
+}
+
+# GRANT SELECT ON TABLE database.schema.view TO role;
+
+resource "materialize_source_grant" "example_source_grant" {
+  role_name     = "role"
+  privilege     = "SELECT"
+  source_name   = "source"
+  schema_name   = "schema"
+  database_name = "database"
+}
+
+# GRANT SELECT ON TABLE database.schema.source TO role;
@@ -0,0 +1,20 @@
+resource "materialize_index" "example_index" {
+  name          = "index"
+  obj_name      = "view"
+  schema_name   = "schema"
+  database_name = "database"
+  cluster_name  = "cluster"
+  col_expr      = ["column_1", "column_2"]
+}
+
+# CREATE INDEX index IN CLUSTER cluster ON database.schema.view (column_1, column_2);
+
+resource "materialize_index" "example_default_index" {
+  obj_name      = "view"
+  schema_name   = "schema"
+  database_name = "database"
+  cluster_name  = "cluster"
+  default       = true
+}
+
+# CREATE DEFAULT INDEX IN CLUSTER cluster ON database.schema.view;
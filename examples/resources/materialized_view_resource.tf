@@ -0,0 +1,17 @@
+resource "materialize_materialized_view" "example_materialized_view" {
+  name          = "materialized_view"
+  schema_name   = "schema"
+  database_name = "database"
+  cluster_name  = "cluster"
+
+  statement = <<SQL
+SELECT
+    *
+FROM
+    materialize.public.table
+SQL
+}
+
+# CREATE MATERIALIZED VIEW database.schema.materialized_view
+#   IN CLUSTER cluster AS
+#   SELECT * FROM materialize.public.table;
@@ -0,0 +1,6 @@
+resource "materialize_role_grant" "example_role_grant" {
+  role_name   = "team"
+  member_name = "service"
+}
+
+# GRANT team TO service;
@@ -0,0 +1,7 @@
+resource "materialize_role" "example_role" {
+  name           = "role"
+  create_db      = true
+  create_cluster = true
+}
+
+# CREATE ROLE role WITH INHERIT CREATEDB CREATECLUSTER;
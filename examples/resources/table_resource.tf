@@ -0,0 +1,30 @@
+resource "materialize_table" "example_table" {
+  name          = "table"
+  schema_name   = "schema"
+  database_name = "database"
+
+  column {
+    name    = "column_1"
+    type    = "text"
+    comment = "Free-form notes"
+  }
+
+  column {
+    name     = "column_2"
+    type     = "int"
+    nullable = false
+  }
+
+  column {
+    name    = "column_3"
+    type    = "text"
+    default = "'unknown'"
+  }
+}
+
+# CREATE TABLE database.schema.table (
+#     column_1 text,
+#     column_2 int NOT NULL,
+#     column_3 text DEFAULT 'unknown'
+# );
+# COMMENT ON COLUMN database.schema.table.column_1 IS 'Free-form notes';
@@ -0,0 +1,15 @@
+resource "materialize_view" "example_view" {
+  name          = "view"
+  schema_name   = "schema"
+  database_name = "database"
+
+  statement = <<SQL
+SELECT
+    *
+FROM
+    materialize.public.table;
+SQL
+}
+
+# CREATE VIEW database.schema.view AS
+#   SELECT * FROM materialize.public.table;
@@ -6,6 +6,8 @@ import (
 	"errors"
 	"log"
 
+	"terraform-materialize/materialize/resources"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -37,9 +39,9 @@ func DatasourceCluster() *schema.Resource {
 func datasourceClusterReplicaRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	conn := meta.(*sql.DB)
+	conn := meta.(*resources.ProviderMeta)
 
-	rows, err := conn.Query(`SELECT * FROM mz_clusters;`)
+	rows, err := resources.QueryRows(ctx, conn, `SELECT * FROM mz_clusters;`, nil)
 	if errors.Is(err, sql.ErrNoRows) {
 		// If not found, mark resource to be removed from statefile during apply or refresh
 		log.Printf("[DEBUG] clusters not found")
@@ -48,7 +50,9 @@ func datasourceClusterReplicaRead(ctx context.Context, d *schema.ResourceData, m
 	} else if err != nil {
 		log.Printf("[DEBUG] unable to parse clusters")
 		d.SetId("")
+		return diag.FromErr(err)
 	}
+	defer rows.Close()
 
 	clusterFormats := []map[string]interface{}{}
 
@@ -4,12 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"terraform-materialize/materialize/datasources"
 	"terraform-materialize/materialize/resources"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	_ "github.com/lib/pq" //PostgreSQL db
 )
 
@@ -47,15 +49,54 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("MZ_DATABASE", "materialize"),
 				Description: "The Materialize database",
 			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("MZ_MAX_RETRIES", 3),
+				Description:  "The maximum number of times a statement or catalog read is retried after a transient error",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"min_backoff": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("MZ_MIN_BACKOFF", "500ms"),
+				Description:  "The delay before the first retry, doubled after each further retry",
+				ValidateFunc: validateDuration,
+			},
+			"max_backoff": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("MZ_MAX_BACKOFF", "10s"),
+				Description:  "The maximum delay between retries",
+				ValidateFunc: validateDuration,
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"materialize_cluster":         resources.Cluster(),
-			"materialize_cluster_replica": resources.ClusterReplica(),
-			"materialize_database":        resources.Database(),
-			"materialize_schema":          resources.Schema(),
-			"materialize_secret":          resources.Secret(),
-			"materialize_sink":            resources.Sink(),
-			"materialize_source":          resources.Source(),
+			"materialize_cluster":                              resources.Cluster(),
+			"materialize_cluster_grant":                        resources.GrantCluster(),
+			"materialize_cluster_replica":                      resources.ClusterReplica(),
+			"materialize_connection_aws_privatelink":           resources.ConnectionAwsPrivateLink(),
+			"materialize_connection_confluent_schema_registry": resources.ConnectionConfluentSchemaRegistry(),
+			"materialize_connection_kafka":                     resources.ConnectionKafka(),
+			"materialize_connection_postgres":                  resources.ConnectionPostgres(),
+			"materialize_connection_ssh_tunnel":                resources.ConnectionSshTunnel(),
+			"materialize_database":                             resources.Database(),
+			"materialize_database_grant":                       resources.GrantDatabase(),
+			"materialize_default_privilege":                    resources.DefaultPrivilege(),
+			"materialize_index":                                resources.Index(),
+			"materialize_materialized_view":                    resources.MaterializedView(),
+			"materialize_role":                                 resources.Role(),
+			"materialize_role_grant":                           resources.RoleGrant(),
+			"materialize_schema":                               resources.Schema(),
+			"materialize_schema_grant":                         resources.GrantSchema(),
+			"materialize_secret":                               resources.Secret(),
+			"materialize_sink":                                 resources.Sink(),
+			"materialize_source":                               resources.Source(),
+			"materialize_source_grant":                         resources.GrantSource(),
+			"materialize_table":                                resources.Table(),
+			"materialize_table_grant":                          resources.GrantTable(),
+			"materialize_view":                                 resources.View(),
+			"materialize_view_grant":                           resources.GrantView(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"materialize_cluster": datasources.DatasourceCluster(),
@@ -68,6 +109,13 @@ func connectionString(host string, username string, password string, port int, d
 	return fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=require", username, password, host, port, database)
 }
 
+func validateDuration(v interface{}, k string) (ws []string, es []error) {
+	if _, err := time.ParseDuration(v.(string)); err != nil {
+		es = append(es, fmt.Errorf("%q must be a duration such as 500ms or 10s: %s", k, err))
+	}
+	return
+}
+
 func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	host := d.Get("host").(string)
 	username := d.Get("username").(string)
@@ -78,6 +126,13 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 	connStr := connectionString(host, username, password, port, database)
 
 	var diags diag.Diagnostics
+
+	minBackoff, _ := time.ParseDuration(d.Get("min_backoff").(string))
+	maxBackoff, _ := time.ParseDuration(d.Get("max_backoff").(string))
+	if maxBackoff < minBackoff {
+		return nil, diag.Errorf("max_backoff (%s) must not be shorter than min_backoff (%s)", maxBackoff, minBackoff)
+	}
+
 	db, err := sql.Open("postgres", connStr)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
@@ -88,5 +143,13 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		return nil, diags
 	}
 
-	return db, diags
+	return &resources.ProviderMeta{
+		DB:       db,
+		Database: database,
+		Retry: resources.RetryConfig{
+			MaxRetries: d.Get("max_retries").(int),
+			MinBackoff: minBackoff,
+			MaxBackoff: maxBackoff,
+		},
+	}, diags
 }
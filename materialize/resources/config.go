@@ -6,6 +6,13 @@ var connectionTypes = []string{
 	"LOAD GENERATOR",
 }
 
+// Source types reported by mz_sources for each connection type
+var sourceConnectionTypes = map[string]string{
+	"kafka":          "KAFKA",
+	"postgres":       "POSTGRES",
+	"load-generator": "LOAD GENERATOR",
+}
+
 var envelopes = []string{
 	"DEBEZIUM",
 	"UPSERT",
@@ -18,6 +25,71 @@ var loadGeneratorTypes = []string{
 	"NONE",
 }
 
+var saslMechanisms = []string{
+	"PLAIN",
+	"SCRAM-SHA-256",
+	"SCRAM-SHA-512",
+}
+
+var sslModes = []string{
+	"disable",
+	"allow",
+	"prefer",
+	"require",
+	"verify-ca",
+	"verify-full",
+}
+
+var databasePrivileges = []string{
+	"USAGE",
+	"CREATE",
+}
+
+var schemaPrivileges = []string{
+	"USAGE",
+	"CREATE",
+}
+
+var clusterPrivileges = []string{
+	"USAGE",
+	"CREATE",
+}
+
+var tablePrivileges = []string{
+	"SELECT",
+	"INSERT",
+	"UPDATE",
+	"DELETE",
+}
+
+var viewPrivileges = []string{
+	"SELECT",
+}
+
+var sourcePrivileges = []string{
+	"SELECT",
+}
+
+var defaultPrivilegeObjectTypes = []string{
+	"TABLE",
+	"TYPE",
+	"SECRET",
+	"CONNECTION",
+	"DATABASE",
+	"SCHEMA",
+	"CLUSTER",
+}
+
+// Abbreviations used for privileges in mz_default_privileges
+var privilegeAbbreviations = map[string]string{
+	"SELECT": "r",
+	"INSERT": "a",
+	"UPDATE": "w",
+	"DELETE": "d",
+	"USAGE":  "U",
+	"CREATE": "C",
+}
+
 var regions = []string{
 	"us-east-1",
 	"eu-west-1",
@@ -4,19 +4,54 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"log"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
 )
 
 func Cluster() *schema.Resource {
-	return &schema.Resource{
+	return importDefaults(&schema.Resource{
 		Description: "A logical cluster, which contains dataflow-powered objects.",
 
 		CreateContext: resourceClusterCreate,
 		ReadContext:   resourceClusterRead,
+		UpdateContext: resourceClusterUpdate,
 		DeleteContext: resourceClusterDelete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: importQualifiedName(resourceClusterRead, "name"),
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			// A blue/green resize waits for the new replicas to hydrate
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			// Switching between managed and unmanaged replicas recreates the cluster
+			if d.HasChange("size") && d.Id() != "" {
+				o, n := d.GetChange("size")
+				if (o.(string) == "") != (n.(string) == "") {
+					return d.ForceNew("size")
+				}
+
+				// A blue/green resize waits for new replicas that would never exist
+				if d.Get("blue_green_resize").(bool) && d.Get("replication_factor").(int) == 0 {
+					return fmt.Errorf("blue_green_resize requires a replication_factor of at least 1")
+				}
+			}
+			return nil
+		},
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Description: "A name for the cluster.",
@@ -24,12 +59,83 @@ func Cluster() *schema.Resource {
 				Required:    true,
 				ForceNew:    true,
 			},
+			"size": {
+				Description:  "The size of the managed cluster. If not specified, replicas must be managed with the cluster replica resource.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(replicaSizes, true),
+			},
+			"replication_factor": {
+				Description:  "The number of replicas of a managed cluster.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				RequiredWith: []string{"size"},
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"availability_zones": {
+				Description: "The availability zones the replicas of a managed cluster may be placed in.",
+				Type:        schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional:     true,
+				RequiredWith: []string{"size"},
+			},
+			"introspection_interval": {
+				Description:      "The interval at which to collect introspection data for a managed cluster.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				DiffSuppressFunc: suppressDurationDiff,
+				RequiredWith:     []string{"size"},
+			},
+			"introspection_debugging": {
+				Description:  "Whether to introspect the gathering of the introspection data for a managed cluster.",
+				Type:         schema.TypeBool,
+				Optional:     true,
+				RequiredWith: []string{"size"},
+			},
+			"blue_green_resize": {
+				Description: "Resize a managed cluster by creating replicas at the new size, waiting for them to hydrate and only then dropping the previous replicas.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"hydration_timeout": {
+				Description: "How long a blue/green resize waits for the new replicas to hydrate before reverting to the previous replicas. Must be shorter than the update timeout.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "10m",
+				ValidateFunc: func(v interface{}, k string) (ws []string, es []error) {
+					if _, err := time.ParseDuration(v.(string)); err != nil {
+						es = append(es, fmt.Errorf("%q must be a duration such as 10m: %s", k, err))
+					}
+					return
+				},
+			},
+			"ownership_role": {
+				Description: "The owner of the object.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"comment": {
+				Description: "A comment describing the object.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
 		},
-	}
+	})
 }
 
 type ClusterBuilder struct {
-	clusterName string
+	clusterName            string
+	size                   string
+	replicationFactor      *int
+	availabilityZones      []string
+	introspectionInterval  string
+	introspectionDebugging bool
 }
 
 func newClusterBuilder(clusterName string) *ClusterBuilder {
@@ -38,56 +144,518 @@ func newClusterBuilder(clusterName string) *ClusterBuilder {
 	}
 }
 
+func (b *ClusterBuilder) Size(s string) *ClusterBuilder {
+	b.size = s
+	return b
+}
+
+func (b *ClusterBuilder) ReplicationFactor(r int) *ClusterBuilder {
+	b.replicationFactor = &r
+	return b
+}
+
+func (b *ClusterBuilder) AvailabilityZones(z []string) *ClusterBuilder {
+	b.availabilityZones = z
+	return b
+}
+
+func (b *ClusterBuilder) IntrospectionInterval(i string) *ClusterBuilder {
+	b.introspectionInterval = i
+	return b
+}
+
+func (b *ClusterBuilder) IntrospectionDebugging() *ClusterBuilder {
+	b.introspectionDebugging = true
+	return b
+}
+
 func (b *ClusterBuilder) Create() string {
-	// Only create empty clusters, manage replicas with separate resource
-	return fmt.Sprintf(`CREATE CLUSTER %s REPLICAS ();`, b.clusterName)
+	// Without a size the cluster is created empty and its replicas are
+	// managed with the separate cluster replica resource
+	if b.size == "" {
+		return fmt.Sprintf(`CREATE CLUSTER %s REPLICAS ();`, quoteIdentifier(b.clusterName))
+	}
+
+	return fmt.Sprintf(`CREATE CLUSTER %s (%s);`, quoteIdentifier(b.clusterName), b.managedOptions())
+}
+
+func (b *ClusterBuilder) managedOptions() string {
+	p := []string{fmt.Sprintf(`SIZE = %s`, quoteString(b.size))}
+
+	// A replication factor of 0 is set explicitly to create no replicas
+	if b.replicationFactor != nil {
+		p = append(p, fmt.Sprintf(`REPLICATION FACTOR = %d`, *b.replicationFactor))
+	}
+
+	if len(b.availabilityZones) > 0 {
+		p = append(p, fmt.Sprintf(`AVAILABILITY ZONES = (%s)`, quoteStrings(b.availabilityZones)))
+	}
+
+	if b.introspectionInterval != "" {
+		p = append(p, fmt.Sprintf(`INTROSPECTION INTERVAL = %s`, quoteString(b.introspectionInterval)))
+	}
+
+	if b.introspectionDebugging {
+		p = append(p, `INTROSPECTION DEBUGGING = TRUE`)
+	}
+
+	return strings.Join(p[:], ", ")
 }
 
-func (b *ClusterBuilder) Read() string {
-	return fmt.Sprintf(`SELECT id, name FROM mz_clusters WHERE name = '%s';`, b.clusterName)
+func (b *ClusterBuilder) Read() (string, []interface{}) {
+	return `
+		SELECT
+			mz_clusters.id,
+			mz_clusters.name,
+			mz_clusters.managed,
+			mz_clusters.size,
+			mz_clusters.replication_factor,
+			mz_clusters.availability_zones,
+			EXTRACT(EPOCH FROM mz_clusters.introspection_interval),
+			mz_clusters.introspection_debugging,
+			mz_roles.name,
+			mz_comments.comment
+		FROM mz_clusters JOIN mz_roles
+			ON mz_clusters.owner_id = mz_roles.id
+		LEFT JOIN mz_internal.mz_comments
+			ON mz_clusters.id = mz_comments.id
+			AND mz_comments.object_type = 'cluster'
+			AND mz_comments.object_sub_id IS NULL
+		WHERE mz_clusters.name = $1;
+	`, []interface{}{b.clusterName}
+}
+
+func (b *ClusterBuilder) ReadReplicas() (string, []interface{}) {
+	return `
+		SELECT mz_cluster_replicas.name
+		FROM mz_cluster_replicas
+		JOIN mz_clusters
+			ON mz_cluster_replicas.cluster_id = mz_clusters.id
+		WHERE mz_clusters.name = $1;
+	`, []interface{}{b.clusterName}
+}
+
+// Counts the indexes and materialized views maintained by the cluster and
+// how many of them are hydrated across the given replicas. Objects without a
+// hydration status on a replica count as not hydrated.
+func (b *ClusterBuilder) ReadHydration(replicaNames []string) (string, []interface{}) {
+	return `
+		WITH objects AS (
+			SELECT mz_indexes.id
+			FROM mz_indexes
+			JOIN mz_clusters
+				ON mz_indexes.cluster_id = mz_clusters.id
+			WHERE mz_clusters.name = $1
+			UNION ALL
+			SELECT mz_materialized_views.id
+			FROM mz_materialized_views
+			JOIN mz_clusters
+				ON mz_materialized_views.cluster_id = mz_clusters.id
+			WHERE mz_clusters.name = $1
+		)
+		SELECT
+			(SELECT count(*) FROM objects),
+			(
+				SELECT count(*)
+				FROM mz_internal.mz_hydration_statuses
+				JOIN mz_cluster_replicas
+					ON mz_hydration_statuses.replica_id = mz_cluster_replicas.id
+				JOIN mz_clusters
+					ON mz_cluster_replicas.cluster_id = mz_clusters.id
+				WHERE mz_clusters.name = $1
+				AND mz_cluster_replicas.name = ANY($2)
+				AND mz_hydration_statuses.object_id IN (SELECT id FROM objects)
+				AND mz_hydration_statuses.hydrated
+			);
+	`, []interface{}{b.clusterName, pq.Array(replicaNames)}
+}
+
+func (b *ClusterBuilder) alter(option string) string {
+	return fmt.Sprintf(`ALTER CLUSTER %s SET (%s);`, quoteIdentifier(b.clusterName), option)
+}
+
+func (b *ClusterBuilder) Resize(newSize string) string {
+	return b.alter(fmt.Sprintf(`SIZE = %s`, quoteString(newSize)))
+}
+
+func (b *ClusterBuilder) AlterReplicationFactor(replicationFactor int) string {
+	return b.alter(fmt.Sprintf(`REPLICATION FACTOR = %d`, replicationFactor))
+}
+
+func (b *ClusterBuilder) AlterAvailabilityZones(zones []string) string {
+	return b.alter(fmt.Sprintf(`AVAILABILITY ZONES = (%s)`, quoteStrings(zones)))
+}
+
+func (b *ClusterBuilder) AlterIntrospectionInterval(interval string) string {
+	if interval == "" {
+		return b.alter(`INTROSPECTION INTERVAL = DEFAULT`)
+	}
+	return b.alter(fmt.Sprintf(`INTROSPECTION INTERVAL = %s`, quoteString(interval)))
+}
+
+func (b *ClusterBuilder) AlterIntrospectionDebugging(enabled bool) string {
+	return b.alter(fmt.Sprintf(`INTROSPECTION DEBUGGING = %s`, strings.ToUpper(fmt.Sprint(enabled))))
+}
+
+func (b *ClusterBuilder) AlterUnmanaged() string {
+	return b.alter(`MANAGED = false`)
+}
+
+// Returns the cluster to managed replicas using the options set on the builder
+func (b *ClusterBuilder) AlterManaged() string {
+	return b.alter(fmt.Sprintf(`MANAGED, %s`, b.managedOptions()))
+}
+
+func (b *ClusterBuilder) AlterOwner(roleName string) string {
+	return fmt.Sprintf(`ALTER CLUSTER %s OWNER TO %s;`, quoteIdentifier(b.clusterName), quoteIdentifier(roleName))
+}
+
+func (b *ClusterBuilder) Comment(comment string) string {
+	if comment == "" {
+		return fmt.Sprintf(`COMMENT ON CLUSTER %s IS NULL;`, quoteIdentifier(b.clusterName))
+	}
+	return fmt.Sprintf(`COMMENT ON CLUSTER %s IS %s;`, quoteIdentifier(b.clusterName), quoteString(comment))
 }
 
 func (b *ClusterBuilder) Drop() string {
-	return fmt.Sprintf(`DROP CLUSTER %s;`, b.clusterName)
+	return fmt.Sprintf(`DROP CLUSTER %s;`, quoteIdentifier(b.clusterName))
+}
+
+func clusterBuilderFromData(d *schema.ResourceData) *ClusterBuilder {
+	clusterName := d.Get("name").(string)
+
+	builder := newClusterBuilder(clusterName)
+
+	if v, ok := d.GetOk("size"); ok {
+		builder.Size(v.(string))
+	}
+
+	if v, ok := d.GetOkExists("replication_factor"); ok {
+		builder.ReplicationFactor(v.(int))
+	}
+
+	if v, ok := d.GetOk("availability_zones"); ok {
+		builder.AvailabilityZones(sliceOfStrings(v))
+	}
+
+	if v, ok := d.GetOk("introspection_interval"); ok {
+		builder.IntrospectionInterval(v.(string))
+	}
+
+	if v, ok := d.GetOk("introspection_debugging"); ok && v.(bool) {
+		builder.IntrospectionDebugging()
+	}
+
+	return builder
 }
 
 func resourceClusterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	conn := meta.(*sql.DB)
+	conn := meta.(*ProviderMeta)
 	clusterName := d.Get("name").(string)
 
 	builder := newClusterBuilder(clusterName)
-	q := builder.Read()
+	q, args := builder.Read()
 
-	var id, name string
-	conn.QueryRow(q).Scan(&id, &name)
+	var id, name, owner string
+	var managed bool
+	var size, comment sql.NullString
+	var replicationFactor sql.NullInt64
+	var availabilityZones pq.StringArray
+	var introspectionInterval sql.NullFloat64
+	var introspectionDebugging sql.NullBool
+	if err := queryRow(ctx, conn, q, args, &id, &name, &managed, &size, &replicationFactor, &availabilityZones, &introspectionInterval, &introspectionDebugging, &owner, &comment); err == sql.ErrNoRows {
+		log.Printf("[WARN] cluster (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return diags
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
 
 	d.SetId(id)
+	d.Set("name", name)
+
+	if managed {
+		d.Set("size", size.String)
+		d.Set("replication_factor", replicationFactor.Int64)
+		d.Set("availability_zones", []string(availabilityZones))
+		d.Set("introspection_debugging", introspectionDebugging.Bool)
+
+		// Clusters without introspection report no interval
+		if introspectionInterval.Valid {
+			d.Set("introspection_interval", time.Duration(introspectionInterval.Float64*float64(time.Second)).String())
+		} else {
+			d.Set("introspection_interval", "")
+		}
+	} else {
+		d.Set("size", "")
+	}
+
+	d.Set("ownership_role", owner)
+	d.Set("comment", comment.String)
 
 	return diags
 }
 
 func resourceClusterCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	conn := meta.(*sql.DB)
-	clusterName := d.Get("name").(string)
+	conn := meta.(*ProviderMeta)
 
-	builder := newClusterBuilder(clusterName)
+	builder := clusterBuilderFromData(d)
 	q := builder.Create()
 
-	ExecResource(conn, q)
+	if diags := ExecResource(ctx, conn, q); diags.HasError() {
+		return diags
+	}
+
+	if v, ok := d.GetOk("ownership_role"); ok {
+		if diags := ExecResource(ctx, conn, builder.AlterOwner(v.(string))); diags.HasError() {
+			return diags
+		}
+	}
+
+	if v, ok := d.GetOk("comment"); ok {
+		if diags := ExecResource(ctx, conn, builder.Comment(v.(string))); diags.HasError() {
+			return diags
+		}
+	}
+
 	return resourceClusterRead(ctx, d, meta)
 }
 
-func resourceClusterDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
+func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*ProviderMeta)
+	clusterName := d.Get("name").(string)
+
+	// Keep the prior state if an option fails to apply, rather than the
+	// planned values, until the cluster is read back
+	d.Partial(true)
+
+	// Managed clusters are reconfigured in place, switching between managed
+	// and unmanaged replicas recreates the cluster
+	swapped := false
+	if d.HasChange("size") && d.Get("blue_green_resize").(bool) {
+		if diags := resourceClusterBlueGreenResize(ctx, d, conn); diags.HasError() {
+			return diags
+		}
+		swapped = true
+	} else if d.HasChange("size") {
+		_, newSize := d.GetChange("size")
+
+		builder := newClusterBuilder(clusterName)
+		q := builder.Resize(newSize.(string))
+
+		if diags := ExecResource(ctx, conn, q); diags.HasError() {
+			return diags
+		}
+	}
+
+	// A blue/green resize already applied the replication factor
+	if d.HasChange("replication_factor") && !swapped {
+		_, newReplicationFactor := d.GetChange("replication_factor")
+
+		builder := newClusterBuilder(clusterName)
+		q := builder.AlterReplicationFactor(newReplicationFactor.(int))
 
-	conn := meta.(*sql.DB)
+		if diags := ExecResource(ctx, conn, q); diags.HasError() {
+			return diags
+		}
+	}
+
+	if d.HasChange("availability_zones") {
+		_, newZones := d.GetChange("availability_zones")
+
+		builder := newClusterBuilder(clusterName)
+		q := builder.AlterAvailabilityZones(sliceOfStrings(newZones))
+
+		if diags := ExecResource(ctx, conn, q); diags.HasError() {
+			return diags
+		}
+	}
+
+	if d.HasChange("introspection_interval") {
+		_, newInterval := d.GetChange("introspection_interval")
+
+		builder := newClusterBuilder(clusterName)
+		q := builder.AlterIntrospectionInterval(newInterval.(string))
+
+		if diags := ExecResource(ctx, conn, q); diags.HasError() {
+			return diags
+		}
+	}
+
+	if d.HasChange("introspection_debugging") {
+		_, newDebugging := d.GetChange("introspection_debugging")
+
+		builder := newClusterBuilder(clusterName)
+		q := builder.AlterIntrospectionDebugging(newDebugging.(bool))
+
+		if diags := ExecResource(ctx, conn, q); diags.HasError() {
+			return diags
+		}
+	}
+
+	if d.HasChange("ownership_role") {
+		_, newRole := d.GetChange("ownership_role")
+
+		builder := newClusterBuilder(clusterName)
+		q := builder.AlterOwner(newRole.(string))
+
+		if diags := ExecResource(ctx, conn, q); diags.HasError() {
+			return diags
+		}
+	}
+
+	if d.HasChange("comment") {
+		_, newComment := d.GetChange("comment")
+
+		builder := newClusterBuilder(clusterName)
+		q := builder.Comment(newComment.(string))
+
+		if diags := ExecResource(ctx, conn, q); diags.HasError() {
+			return diags
+		}
+	}
+
+	d.Partial(false)
+	return resourceClusterRead(ctx, d, meta)
+}
+
+// Resizes a managed cluster without downtime. The cluster is temporarily
+// switched to unmanaged replicas so replacements at the new size can run
+// alongside the existing replicas until every object has hydrated on them.
+func resourceClusterBlueGreenResize(ctx context.Context, d *schema.ResourceData, conn *ProviderMeta) diag.Diagnostics {
+	clusterName := d.Get("name").(string)
+	timeout, _ := time.ParseDuration(d.Get("hydration_timeout").(string))
+
+	cluster := clusterBuilderFromData(d)
+
+	// The managed configuration to return to if the new replicas never hydrate
+	oldSize, _ := d.GetChange("size")
+	oldReplicationFactor, _ := d.GetChange("replication_factor")
+	oldZones, _ := d.GetChange("availability_zones")
+	oldInterval, _ := d.GetChange("introspection_interval")
+	oldDebugging, _ := d.GetChange("introspection_debugging")
+
+	previous := newClusterBuilder(clusterName).Size(oldSize.(string)).ReplicationFactor(oldReplicationFactor.(int))
+	if zones := sliceOfStrings(oldZones); len(zones) > 0 {
+		previous.AvailabilityZones(zones)
+	}
+	if oldInterval.(string) != "" {
+		previous.IntrospectionInterval(oldInterval.(string))
+	}
+	if oldDebugging.(bool) {
+		previous.IntrospectionDebugging()
+	}
+
+	q, args := cluster.ReadReplicas()
+	rows, err := QueryRows(ctx, conn, q, args)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer rows.Close()
+
+	var oldReplicas []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return diag.FromErr(err)
+		}
+		oldReplicas = append(oldReplicas, name)
+	}
+
+	if diags := ExecResource(ctx, conn, cluster.AlterUnmanaged()); diags.HasError() {
+		return diags
+	}
+
+	var newReplicas []*ClusterReplicaBuilder
+	var newReplicaNames []string
+
+	// Drops the replicas created so far and hands the cluster back to the
+	// previous managed configuration. The rollback runs under its own timeout
+	// as the update context may already have expired.
+	rollback := func(diags diag.Diagnostics) diag.Diagnostics {
+		ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutDelete))
+		defer cancel()
+
+		log.Printf("[WARN] cluster %s: dropping replicas %s", clusterName, strings.Join(newReplicaNames, ", "))
+		for _, replica := range newReplicas {
+			diags = append(diags, ExecResource(ctx, conn, replica.Drop())...)
+		}
+		return append(diags, ExecResource(ctx, conn, previous.AlterManaged())...)
+	}
+
+	for i := 1; i <= d.Get("replication_factor").(int); i++ {
+		replica := newClusterReplicaBuilder(clusterName, resource.PrefixedUniqueId(fmt.Sprintf("r%d_", i))).Size(cluster.size)
+
+		// Spread the replicas across the zones the cluster may be placed in
+		if zones := cluster.availabilityZones; len(zones) > 0 {
+			replica.AvailabilityZone(zones[(i-1)%len(zones)])
+		}
+
+		if cluster.introspectionInterval != "" {
+			replica.IntrospectionInterval(cluster.introspectionInterval)
+		}
+
+		if cluster.introspectionDebugging {
+			replica.IntrospectionDebugging()
+		}
+
+		log.Printf("[INFO] cluster %s: creating replica %s with size %s", clusterName, replica.replicaName, cluster.size)
+		if diags := ExecResource(ctx, conn, replica.Create()); diags.HasError() {
+			return rollback(diags)
+		}
+
+		newReplicas = append(newReplicas, replica)
+		newReplicaNames = append(newReplicaNames, replica.replicaName)
+	}
+
+	log.Printf("[INFO] cluster %s: waiting up to %s for replicas %s to hydrate", clusterName, timeout, strings.Join(newReplicaNames, ", "))
+	err = resource.RetryContext(ctx, timeout, func() *resource.RetryError {
+		q, args := cluster.ReadHydration(newReplicaNames)
+
+		var objects, hydrated int
+		if err := queryRow(ctx, conn, q, args, &objects, &hydrated); err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		// Every object must be hydrated on every new replica
+		if expected := objects * len(newReplicaNames); hydrated < expected {
+			log.Printf("[INFO] cluster %s: %d of %d objects hydrated", clusterName, hydrated, expected)
+			return resource.RetryableError(fmt.Errorf("cluster %s has %d of %d objects hydrated", clusterName, hydrated, expected))
+		}
+		return nil
+	})
+
+	if err != nil {
+		// Keep serving from the previous replicas
+		return rollback(diag.Errorf("error resizing cluster %s: %s", clusterName, err))
+	}
+
+	for _, name := range oldReplicas {
+		log.Printf("[INFO] cluster %s: dropping replica %s", clusterName, name)
+		if diags := ExecResource(ctx, conn, newClusterReplicaBuilder(clusterName, name).Drop()); diags.HasError() {
+			return diags
+		}
+	}
+
+	// Managed clusters expect their replicas to be named r1 through rN
+	for i, replica := range newReplicas {
+		if diags := ExecResource(ctx, conn, replica.Rename(fmt.Sprintf("r%d", i+1))); diags.HasError() {
+			return diags
+		}
+	}
+
+	log.Printf("[INFO] cluster %s: resized to %s", clusterName, cluster.size)
+	return ExecResource(ctx, conn, cluster.AlterManaged())
+}
+
+func resourceClusterDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*ProviderMeta)
 	clusterName := d.Get("name").(string)
 
 	builder := newClusterBuilder(clusterName)
 	q := builder.Drop()
 
-	ExecResource(conn, q)
-	return diags
+	return ExecResource(ctx, conn, q)
 }
@@ -4,27 +4,41 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"log"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func ClusterReplica() *schema.Resource {
-	return &schema.Resource{
-		Description: "A cluster replica is the physical resource which maintains dataflow-powered objects.",
+	return importDefaults(&schema.Resource{
+		Description: "A cluster replica is the physical resource which maintains dataflow-powered objects. Renaming a replica or changing its owner alters it in place. Materialize cannot alter the size, availability zone, introspection options or idle arrangement merge effort of a replica, so changing them replaces it under a temporary name before the existing replica is dropped.",
 
 		CreateContext: resourceClusterReplicaCreate,
 		ReadContext:   resourceClusterReplicaRead,
+		UpdateContext: resourceClusterReplicaUpdate,
 		DeleteContext: resourceClusterReplicaDelete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: importQualifiedName(resourceClusterReplicaRead, "cluster_name", "name"),
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Description: "A name for this replica.",
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
 			},
 			"cluster_name": {
 				Description: "The cluster whose resources you want to create an additional computation of.",
@@ -36,38 +50,46 @@ func ClusterReplica() *schema.Resource {
 				Description:  "The size of the replica.",
 				Type:         schema.TypeString,
 				Required:     true,
-				ForceNew:     true,
 				ValidateFunc: validation.StringInSlice(replicaSizes, true),
 			},
 			"availability_zone": {
 				Description:  "If you want the replica to reside in a specific availability zone.",
 				Type:         schema.TypeString,
 				Optional:     true,
-				ForceNew:     true,
+				Computed:     true,
 				ValidateFunc: validation.StringInSlice(regions, true),
 			},
 			"introspection_interval": {
 				Description: "The interval at which to collect introspection data.",
 				Type:        schema.TypeString,
 				Optional:    true,
-				ForceNew:    true,
 				Default:     "1s",
 			},
 			"introspection_debugging": {
 				Description: "Whether to introspect the gathering of the introspection data.",
 				Type:        schema.TypeBool,
 				Optional:    true,
-				ForceNew:    true,
+				Default:     false,
+			},
+			"wait_for_ready": {
+				Description: "Wait until every process of the replica is ready before completing the create or replacement.",
+				Type:        schema.TypeBool,
+				Optional:    true,
 				Default:     false,
 			},
 			"idle_arrangement_merge_effort": {
 				Description: "The amount of effort the replica should exert on compacting arrangements during idle periods. This is an unstable option! It may be changed or removed at any time.",
 				Type:        schema.TypeInt,
 				Optional:    true,
-				ForceNew:    true,
+			},
+			"ownership_role": {
+				Description: "The owner of the object.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
 			},
 		},
-	}
+	})
 }
 
 type ClusterReplicaBuilder struct {
@@ -114,18 +136,18 @@ func (b *ClusterReplicaBuilder) IdleArrangementMergeEffort(e int) *ClusterReplic
 
 func (b *ClusterReplicaBuilder) Create() string {
 	q := strings.Builder{}
-	q.WriteString(fmt.Sprintf(`CREATE CLUSTER REPLICA %s.%s`, b.clusterName, b.replicaName))
+	q.WriteString(fmt.Sprintf(`CREATE CLUSTER REPLICA %s`, qualifiedName(b.clusterName, b.replicaName)))
 
 	if b.size != "" {
-		q.WriteString(fmt.Sprintf(` SIZE = '%s'`, b.size))
+		q.WriteString(fmt.Sprintf(` SIZE = %s`, quoteString(b.size)))
 	}
 
 	if b.availabilityZone != "" {
-		q.WriteString(fmt.Sprintf(` AVAILABILITY ZONE = '%s'`, b.availabilityZone))
+		q.WriteString(fmt.Sprintf(` AVAILABILITY ZONE = %s`, quoteString(b.availabilityZone)))
 	}
 
 	if b.introspectionInterval != "" {
-		q.WriteString(fmt.Sprintf(` INTROSPECTION INTERVAL = '%s'`, b.introspectionInterval))
+		q.WriteString(fmt.Sprintf(` INTROSPECTION INTERVAL = %s`, quoteString(b.introspectionInterval)))
 	}
 
 	if b.introspectionDebugging {
@@ -140,88 +162,243 @@ func (b *ClusterReplicaBuilder) Create() string {
 	return q.String()
 }
 
-func (b *ClusterReplicaBuilder) Read() string {
-	return fmt.Sprintf(`
+func (b *ClusterReplicaBuilder) Read() (string, []interface{}) {
+	return `
 		SELECT
 			mz_cluster_replicas.id,
 			mz_cluster_replicas.name,
 			mz_clusters.name,
 			mz_cluster_replicas.size,
-			mz_cluster_replicas.availability_zone
+			mz_cluster_replicas.availability_zone,
+			mz_roles.name
 		FROM mz_cluster_replicas
 		JOIN mz_clusters
 			ON mz_cluster_replicas.cluster_id = mz_clusters.id
-		WHERE mz_cluster_replicas.name = '%s'
-		AND mz_clusters.name = '%s';
-	`, b.replicaName, b.clusterName)
+		JOIN mz_roles
+			ON mz_cluster_replicas.owner_id = mz_roles.id
+		WHERE mz_cluster_replicas.name = $1
+		AND mz_clusters.name = $2;
+	`, []interface{}{b.replicaName, b.clusterName}
+}
+
+// Reports the status of the least ready process of the replica
+func (b *ClusterReplicaBuilder) ReadStatus() (string, []interface{}) {
+	return `
+		SELECT
+			mz_cluster_replica_statuses.status,
+			mz_cluster_replica_statuses.reason
+		FROM mz_internal.mz_cluster_replica_statuses
+		JOIN mz_cluster_replicas
+			ON mz_cluster_replica_statuses.replica_id = mz_cluster_replicas.id
+		JOIN mz_clusters
+			ON mz_cluster_replicas.cluster_id = mz_clusters.id
+		WHERE mz_cluster_replicas.name = $1
+		AND mz_clusters.name = $2
+		ORDER BY mz_cluster_replica_statuses.status = 'ready'
+		LIMIT 1;
+	`, []interface{}{b.replicaName, b.clusterName}
+}
+
+func (b *ClusterReplicaBuilder) Rename(newName string) string {
+	return fmt.Sprintf(`ALTER CLUSTER REPLICA %s RENAME TO %s;`, qualifiedName(b.clusterName, b.replicaName), quoteIdentifier(newName))
+}
+
+func (b *ClusterReplicaBuilder) AlterOwner(roleName string) string {
+	return fmt.Sprintf(`ALTER CLUSTER REPLICA %s OWNER TO %s;`, qualifiedName(b.clusterName, b.replicaName), quoteIdentifier(roleName))
 }
 
 func (b *ClusterReplicaBuilder) Drop() string {
-	return fmt.Sprintf(`DROP CLUSTER REPLICA %s.%s;`, b.clusterName, b.replicaName)
+	return fmt.Sprintf(`DROP CLUSTER REPLICA %s;`, qualifiedName(b.clusterName, b.replicaName))
+}
+
+func clusterReplicaBuilderFromData(d *schema.ResourceData, replicaName string) *ClusterReplicaBuilder {
+	clusterName := d.Get("cluster_name").(string)
+
+	builder := newClusterReplicaBuilder(clusterName, replicaName)
+
+	if v, ok := d.GetOk("size"); ok {
+		builder.Size(v.(string))
+	}
+
+	if v, ok := d.GetOk("availability_zone"); ok {
+		builder.AvailabilityZone(v.(string))
+	}
+
+	if v, ok := d.GetOk("introspection_interval"); ok {
+		builder.IntrospectionInterval(v.(string))
+	}
+
+	if v, ok := d.GetOk("introspection_debugging"); ok && v.(bool) {
+		builder.IntrospectionDebugging()
+	}
+
+	if v, ok := d.GetOk("idle_arrangement_merge_effort"); ok {
+		builder.IdleArrangementMergeEffort(v.(int))
+	}
+
+	return builder
 }
 
 func resourceClusterReplicaRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	conn := meta.(*sql.DB)
+	conn := meta.(*ProviderMeta)
 	replicaName := d.Get("name").(string)
 	clusterName := d.Get("cluster_name").(string)
 
-	builder := newClusterReplicaBuilder(replicaName, clusterName)
-	q := builder.Read()
-
-	var id, name, cluster, size, availability_zone string
-	conn.QueryRow(q).Scan(&id, &name, &cluster, &size, &availability_zone)
+	builder := newClusterReplicaBuilder(clusterName, replicaName)
+	q, args := builder.Read()
+
+	var id, name, cluster, size, owner string
+	var availabilityZone sql.NullString
+	if err := queryRow(ctx, conn, q, args, &id, &name, &cluster, &size, &availabilityZone, &owner); err == sql.ErrNoRows {
+		log.Printf("[WARN] cluster replica (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return diags
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
 
 	d.SetId(id)
+	d.Set("name", name)
+	d.Set("cluster_name", cluster)
+	d.Set("size", size)
+	d.Set("availability_zone", availabilityZone.String)
+	d.Set("ownership_role", owner)
+
+	// The catalog does not record the introspection options or the idle
+	// arrangement merge effort of a replica, so the configured values are kept
 
 	return diags
 }
 
 func resourceClusterReplicaCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	conn := meta.(*sql.DB)
+	conn := meta.(*ProviderMeta)
 
 	replicaName := d.Get("name").(string)
-	clusterName := d.Get("cluster_name").(string)
 
-	builder := newClusterReplicaBuilder(replicaName, clusterName)
+	builder := clusterReplicaBuilderFromData(d, replicaName)
+	q := builder.Create()
 
-	if v, ok := d.GetOk("size"); ok {
-		builder.Size(v.(string))
+	if diags := ExecResource(ctx, conn, q); diags.HasError() {
+		return diags
 	}
 
-	if v, ok := d.GetOk("availabilityZone"); ok {
-		builder.AvailabilityZone(v.(string))
+	if v, ok := d.GetOk("ownership_role"); ok {
+		if diags := ExecResource(ctx, conn, builder.AlterOwner(v.(string))); diags.HasError() {
+			return diags
+		}
 	}
 
-	if v, ok := d.GetOk("introspectionInterval"); ok {
-		builder.AvailabilityZone(v.(string))
+	diags := resourceClusterReplicaRead(ctx, d, meta)
+	if diags.HasError() || d.Id() == "" {
+		return diags
 	}
 
-	if v, ok := d.GetOk("introspectionDebugging"); ok && v.(bool) {
-		builder.IntrospectionDebugging()
+	if d.Get("wait_for_ready").(bool) {
+		q, args := builder.ReadStatus()
+		if err := waitForReady(ctx, conn, d.Timeout(schema.TimeoutCreate), fmt.Sprintf("cluster replica %s.%s", builder.clusterName, replicaName), "ready", q, args...); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
-	if v, ok := d.GetOk("idleArrangementMergeEffort"); ok {
-		builder.IdleArrangementMergeEffort(v.(int))
+	return diags
+}
+
+func resourceClusterReplicaUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*ProviderMeta)
+	clusterName := d.Get("cluster_name").(string)
+	oldName, newName := d.GetChange("name")
+	replicaName := newName.(string)
+
+	// Keep the prior state if a change fails to apply, rather than the
+	// planned values, until the replica is read back
+	d.Partial(true)
+
+	// Materialize only renames replicas and changes their owner in place.
+	// For any other option create a replacement under a temporary name before
+	// dropping the existing replica so the cluster never runs without
+	// replicas, then give the replacement the configured name.
+	replaced := false
+	if d.HasChanges("size", "availability_zone", "introspection_interval", "introspection_debugging", "idle_arrangement_merge_effort") {
+		tempName := resource.PrefixedUniqueId(replicaName + "_")
+		log.Printf("[DEBUG] replacing cluster replica %s.%s using temporary replica %s", clusterName, oldName, tempName)
+
+		replacement := clusterReplicaBuilderFromData(d, tempName)
+		if diags := ExecResource(ctx, conn, replacement.Create()); diags.HasError() {
+			return diags
+		}
+
+		if d.Get("wait_for_ready").(bool) {
+			q, args := replacement.ReadStatus()
+			if err := waitForReady(ctx, conn, d.Timeout(schema.TimeoutUpdate), fmt.Sprintf("cluster replica %s.%s", clusterName, tempName), "ready", q, args...); err != nil {
+				// Keep the existing replica serving and discard the replacement
+				return dropReplacementReplica(conn, replacement, d.Timeout(schema.TimeoutDelete), diag.FromErr(err))
+			}
+		}
+
+		existing := newClusterReplicaBuilder(clusterName, oldName.(string))
+		if diags := ExecResource(ctx, conn, existing.Drop()); diags.HasError() {
+			return dropReplacementReplica(conn, replacement, d.Timeout(schema.TimeoutDelete), diags)
+		}
+
+		// Both replicas cannot be kept at this point, so leave the replacement
+		// running and report where it can be found
+		if diags := ExecResource(ctx, conn, replacement.Rename(replicaName)); diags.HasError() {
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("cluster replica %s.%s was replaced by temporary replica %s", clusterName, oldName, tempName),
+				Detail:   fmt.Sprintf("Rename the replica %s to %s before applying again.", qualifiedName(clusterName, tempName), quoteIdentifier(replicaName)),
+			})
+		}
+		replaced = true
+	} else if d.HasChange("name") {
+		builder := newClusterReplicaBuilder(clusterName, oldName.(string))
+		q := builder.Rename(replicaName)
+
+		if diags := ExecResource(ctx, conn, q); diags.HasError() {
+			return diags
+		}
 	}
 
-	q := builder.Create()
+	// The replacement is owned by the role that created it
+	if v, ok := d.GetOk("ownership_role"); ok && (replaced || d.HasChange("ownership_role")) {
+		builder := newClusterReplicaBuilder(clusterName, replicaName)
+		q := builder.AlterOwner(v.(string))
 
-	ExecResource(conn, q)
+		if diags := ExecResource(ctx, conn, q); diags.HasError() {
+			return diags
+		}
+	}
+
+	d.Partial(false)
 	return resourceClusterReplicaRead(ctx, d, meta)
 }
 
-func resourceClusterReplicaDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
+// Drops a replacement replica that could not take over from the existing
+// replica, reporting its name if it has to be dropped by hand. The drop runs
+// under its own timeout as the update context may already have expired.
+func dropReplacementReplica(conn *ProviderMeta, replacement *ClusterReplicaBuilder, timeout time.Duration, diags diag.Diagnostics) diag.Diagnostics {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if dropDiags := ExecResource(ctx, conn, replacement.Drop()); dropDiags.HasError() {
+		diags = append(diags, dropDiags...)
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("temporary cluster replica %s.%s could not be dropped", replacement.clusterName, replacement.replicaName),
+		})
+	}
+	return diags
+}
 
-	conn := meta.(*sql.DB)
+func resourceClusterReplicaDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*ProviderMeta)
 	replicaName := d.Get("name").(string)
 	clusterName := d.Get("cluster_name").(string)
 
-	builder := newClusterReplicaBuilder(replicaName, clusterName)
+	builder := newClusterReplicaBuilder(clusterName, replicaName)
 	q := builder.Drop()
 
-	ExecResource(conn, q)
-	return diags
+	return ExecResource(ctx, conn, q)
 }
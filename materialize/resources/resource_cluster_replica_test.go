@@ -1,52 +1,258 @@
 package resources
 
 import (
+	"context"
+	"database/sql"
 	"testing"
 
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 )
 
 func TestResourceClusterReplicaCreate(t *testing.T) {
 	r := require.New(t)
 	b := newClusterReplicaBuilder("cluster", "replica")
-	r.Equal(`CREATE CLUSTER REPLICA cluster.replica;`, b.Create())
+	r.Equal(`CREATE CLUSTER REPLICA "cluster"."replica";`, b.Create())
 
 	b.Size("xsmall")
-	r.Equal(`CREATE CLUSTER REPLICA cluster.replica SIZE = 'xsmall';`, b.Create())
+	r.Equal(`CREATE CLUSTER REPLICA "cluster"."replica" SIZE = 'xsmall';`, b.Create())
 
 	b.AvailabilityZone("us-east-1")
-	r.Equal(`CREATE CLUSTER REPLICA cluster.replica SIZE = 'xsmall' AVAILABILITY ZONE = 'us-east-1';`, b.Create())
+	r.Equal(`CREATE CLUSTER REPLICA "cluster"."replica" SIZE = 'xsmall' AVAILABILITY ZONE = 'us-east-1';`, b.Create())
 
 	b.IntrospectionInterval("1s")
-	r.Equal(`CREATE CLUSTER REPLICA cluster.replica SIZE = 'xsmall' AVAILABILITY ZONE = 'us-east-1' INTROSPECTION INTERVAL = '1s';`, b.Create())
+	r.Equal(`CREATE CLUSTER REPLICA "cluster"."replica" SIZE = 'xsmall' AVAILABILITY ZONE = 'us-east-1' INTROSPECTION INTERVAL = '1s';`, b.Create())
 
 	b.IntrospectionDebugging()
-	r.Equal(`CREATE CLUSTER REPLICA cluster.replica SIZE = 'xsmall' AVAILABILITY ZONE = 'us-east-1' INTROSPECTION INTERVAL = '1s' INTROSPECTION DEBUGGING = TRUE;`, b.Create())
+	r.Equal(`CREATE CLUSTER REPLICA "cluster"."replica" SIZE = 'xsmall' AVAILABILITY ZONE = 'us-east-1' INTROSPECTION INTERVAL = '1s' INTROSPECTION DEBUGGING = TRUE;`, b.Create())
 
 	b.IdleArrangementMergeEffort(1)
-	r.Equal(`CREATE CLUSTER REPLICA cluster.replica SIZE = 'xsmall' AVAILABILITY ZONE = 'us-east-1' INTROSPECTION INTERVAL = '1s' INTROSPECTION DEBUGGING = TRUE IDLE ARRANGEMENT MERGE EFFORT = 1;`, b.Create())
+	r.Equal(`CREATE CLUSTER REPLICA "cluster"."replica" SIZE = 'xsmall' AVAILABILITY ZONE = 'us-east-1' INTROSPECTION INTERVAL = '1s' INTROSPECTION DEBUGGING = TRUE IDLE ARRANGEMENT MERGE EFFORT = 1;`, b.Create())
 }
 
 func TestResourceClusterReplicaRead(t *testing.T) {
 	r := require.New(t)
 	b := newClusterReplicaBuilder("cluster", "replica")
+	q, args := b.Read()
 	r.Equal(`
 		SELECT
 			mz_cluster_replicas.id,
 			mz_cluster_replicas.name,
 			mz_clusters.name,
 			mz_cluster_replicas.size,
-			mz_cluster_replicas.availability_zone
+			mz_cluster_replicas.availability_zone,
+			mz_roles.name
 		FROM mz_cluster_replicas
 		JOIN mz_clusters
 			ON mz_cluster_replicas.cluster_id = mz_clusters.id
-		WHERE mz_cluster_replicas.name = 'replica'
-		AND mz_clusters.name = 'cluster';
-	`, b.Read())
+		JOIN mz_roles
+			ON mz_cluster_replicas.owner_id = mz_roles.id
+		WHERE mz_cluster_replicas.name = $1
+		AND mz_clusters.name = $2;
+	`, q)
+	r.Equal([]interface{}{"replica", "cluster"}, args)
+}
+
+func TestResourceClusterReplicaReadStatus(t *testing.T) {
+	r := require.New(t)
+	b := newClusterReplicaBuilder("cluster", "replica")
+	q, args := b.ReadStatus()
+	r.Equal(`
+		SELECT
+			mz_cluster_replica_statuses.status,
+			mz_cluster_replica_statuses.reason
+		FROM mz_internal.mz_cluster_replica_statuses
+		JOIN mz_cluster_replicas
+			ON mz_cluster_replica_statuses.replica_id = mz_cluster_replicas.id
+		JOIN mz_clusters
+			ON mz_cluster_replicas.cluster_id = mz_clusters.id
+		WHERE mz_cluster_replicas.name = $1
+		AND mz_clusters.name = $2
+		ORDER BY mz_cluster_replica_statuses.status = 'ready'
+		LIMIT 1;
+	`, q)
+	r.Equal([]interface{}{"replica", "cluster"}, args)
+}
+
+func TestResourceClusterReplicaRename(t *testing.T) {
+	r := require.New(t)
+	b := newClusterReplicaBuilder("cluster", "replica")
+	r.Equal(`ALTER CLUSTER REPLICA "cluster"."replica" RENAME TO "new_replica";`, b.Rename("new_replica"))
+}
+
+func TestResourceClusterReplicaAlterOwner(t *testing.T) {
+	r := require.New(t)
+	b := newClusterReplicaBuilder("cluster", "replica")
+	r.Equal(`ALTER CLUSTER REPLICA "cluster"."replica" OWNER TO "role";`, b.AlterOwner("role"))
 }
 
 func TestResourceClusterReplicaDrop(t *testing.T) {
 	r := require.New(t)
 	b := newClusterReplicaBuilder("cluster", "replica")
-	r.Equal(`DROP CLUSTER REPLICA cluster.replica;`, b.Drop())
+	r.Equal(`DROP CLUSTER REPLICA "cluster"."replica";`, b.Drop())
+}
+
+func clusterReplicaUpdateData(t *testing.T, meta interface{}) *schema.ResourceData {
+	return UpdateData(t, ClusterReplica(), "u1", map[string]string{
+		"id":                      "u1",
+		"name":                    "replica",
+		"cluster_name":            "cluster",
+		"size":                    "xsmall",
+		"introspection_interval":  "1s",
+		"introspection_debugging": "false",
+	}, map[string]interface{}{
+		"name":         "replica",
+		"cluster_name": "cluster",
+		"size":         "small",
+	}, meta)
+}
+
+func TestResourceClusterReplicaUpdateDropError(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.MatchExpectationsInOrder(true)
+		mock.ExpectExec(`CREATE CLUSTER REPLICA "cluster"."replica_\w+" SIZE = 'small'`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`DROP CLUSTER REPLICA "cluster"."replica";`).WillReturnError(&pq.Error{Code: "42501", Message: "permission denied"})
+		mock.ExpectExec(`DROP CLUSTER REPLICA "cluster"."replica_\w+";`).WillReturnResult(sqlmock.NewResult(1, 1))
+
+		d := clusterReplicaUpdateData(t, testMeta(db))
+		diags := resourceClusterReplicaUpdate(context.TODO(), d, testMeta(db))
+		r.True(diags.HasError())
+		r.Len(diags, 1)
+		r.Contains(diags[0].Summary, "permission denied")
+	})
+}
+
+func TestResourceClusterReplicaUpdateRenameError(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.MatchExpectationsInOrder(true)
+		mock.ExpectExec(`CREATE CLUSTER REPLICA "cluster"."replica_\w+" SIZE = 'small'`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`DROP CLUSTER REPLICA "cluster"."replica";`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`ALTER CLUSTER REPLICA "cluster"."replica_\w+" RENAME TO "replica";`).WillReturnError(&pq.Error{Code: "42710", Message: "cluster replica 'replica' already exists"})
+
+		d := clusterReplicaUpdateData(t, testMeta(db))
+		diags := resourceClusterReplicaUpdate(context.TODO(), d, testMeta(db))
+		r.True(diags.HasError())
+		r.Len(diags, 2)
+		r.Regexp(`cluster replica cluster.replica was replaced by temporary replica replica_\w+`, diags[1].Summary)
+	})
+}
+
+func TestResourceClusterReplicaUpdateInPlace(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.MatchExpectationsInOrder(true)
+		mock.ExpectExec(`ALTER CLUSTER REPLICA "cluster"."replica" RENAME TO "new_replica";`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`ALTER CLUSTER REPLICA "cluster"."new_replica" OWNER TO "role";`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery(`SELECT\s+mz_cluster_replicas.id`).WithArgs("new_replica", "cluster").WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name", "cluster", "size", "availability_zone", "owner"}).
+				AddRow("u1", "new_replica", "cluster", "xsmall", "use1-az1", "role"),
+		)
+
+		d := UpdateData(t, ClusterReplica(), "u1", map[string]string{
+			"id":                      "u1",
+			"name":                    "replica",
+			"cluster_name":            "cluster",
+			"size":                    "xsmall",
+			"availability_zone":       "use1-az1",
+			"introspection_interval":  "1s",
+			"introspection_debugging": "false",
+			"ownership_role":          "mz_system",
+		}, map[string]interface{}{
+			"name":           "new_replica",
+			"cluster_name":   "cluster",
+			"size":           "xsmall",
+			"ownership_role": "role",
+		}, testMeta(db))
+
+		diags := resourceClusterReplicaUpdate(context.TODO(), d, testMeta(db))
+		r.False(diags.HasError())
+		r.Equal("new_replica", d.State().Attributes["name"])
+		r.Equal("role", d.State().Attributes["ownership_role"])
+	})
+}
+
+func TestResourceClusterReplicaUpdateReplaceRenamed(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.MatchExpectationsInOrder(true)
+		mock.ExpectExec(`CREATE CLUSTER REPLICA "cluster"."new_replica_\w+" SIZE = 'small'`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`DROP CLUSTER REPLICA "cluster"."replica";`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`ALTER CLUSTER REPLICA "cluster"."new_replica_\w+" RENAME TO "new_replica";`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`ALTER CLUSTER REPLICA "cluster"."new_replica" OWNER TO "mz_system";`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery(`SELECT\s+mz_cluster_replicas.id`).WithArgs("new_replica", "cluster").WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name", "cluster", "size", "availability_zone", "owner"}).
+				AddRow("u2", "new_replica", "cluster", "small", "use1-az1", "mz_system"),
+		)
+
+		d := UpdateData(t, ClusterReplica(), "u1", map[string]string{
+			"id":                      "u1",
+			"name":                    "replica",
+			"cluster_name":            "cluster",
+			"size":                    "xsmall",
+			"introspection_interval":  "1s",
+			"introspection_debugging": "false",
+			"ownership_role":          "mz_system",
+		}, map[string]interface{}{
+			"name":         "new_replica",
+			"cluster_name": "cluster",
+			"size":         "small",
+		}, testMeta(db))
+
+		diags := resourceClusterReplicaUpdate(context.TODO(), d, testMeta(db))
+		r.False(diags.HasError())
+		r.Equal("u2", d.Id())
+	})
+}
+
+func TestResourceClusterReplicaUpdateErrorKeepsState(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.ExpectExec(`CREATE CLUSTER REPLICA "cluster"."replica_\w+" SIZE = 'small'`).WillReturnError(&pq.Error{Code: "53000", Message: "insufficient resources"})
+
+		d := clusterReplicaUpdateData(t, testMeta(db))
+		diags := resourceClusterReplicaUpdate(context.TODO(), d, testMeta(db))
+		r.True(diags.HasError())
+		r.Equal("xsmall", d.State().Attributes["size"])
+	})
+}
+
+func TestResourceClusterReplicaUpdateWaitError(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.MatchExpectationsInOrder(true)
+		mock.ExpectExec(`CREATE CLUSTER REPLICA "cluster"."replica_\w+" SIZE = 'small'`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery(`SELECT\s+mz_cluster_replica_statuses.status`).WillReturnRows(sqlmock.NewRows([]string{"status", "reason"}).AddRow("failed", "oom-killed"))
+		mock.ExpectExec(`DROP CLUSTER REPLICA "cluster"."replica_\w+";`).WillReturnError(&pq.Error{Code: "42501", Message: "permission denied"})
+
+		d := UpdateData(t, ClusterReplica(), "u1", map[string]string{
+			"id":                      "u1",
+			"name":                    "replica",
+			"cluster_name":            "cluster",
+			"size":                    "xsmall",
+			"introspection_interval":  "1s",
+			"introspection_debugging": "false",
+			"wait_for_ready":          "true",
+		}, map[string]interface{}{
+			"name":           "replica",
+			"cluster_name":   "cluster",
+			"size":           "small",
+			"wait_for_ready": true,
+		}, testMeta(db))
+
+		diags := resourceClusterReplicaUpdate(context.TODO(), d, testMeta(db))
+		r.Len(diags, 3)
+		r.Contains(diags[0].Summary, "oom-killed")
+		r.Contains(diags[1].Summary, "permission denied")
+		r.Regexp(`temporary cluster replica cluster.replica_\w+ could not be dropped`, diags[2].Summary)
+	})
 }
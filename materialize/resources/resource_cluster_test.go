@@ -1,25 +1,339 @@
 package resources
 
 import (
+	"context"
+	"database/sql"
 	"testing"
+	"time"
 
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 )
 
 func TestResourceClusterCreate(t *testing.T) {
 	r := require.New(t)
 	b := newClusterBuilder("cluster")
-	r.Equal(`CREATE CLUSTER cluster REPLICAS ();`, b.Create())
+	r.Equal(`CREATE CLUSTER "cluster" REPLICAS ();`, b.Create())
+}
+
+func TestResourceClusterCreateManaged(t *testing.T) {
+	r := require.New(t)
+	b := newClusterBuilder("cluster")
+	b.Size("xsmall")
+	r.Equal(`CREATE CLUSTER "cluster" (SIZE = 'xsmall');`, b.Create())
+
+	b.ReplicationFactor(2)
+	b.AvailabilityZones([]string{"use1-az1", "use1-az2"})
+	b.IntrospectionInterval("1s")
+	b.IntrospectionDebugging()
+	r.Equal(`CREATE CLUSTER "cluster" (SIZE = 'xsmall', REPLICATION FACTOR = 2, AVAILABILITY ZONES = ('use1-az1', 'use1-az2'), INTROSPECTION INTERVAL = '1s', INTROSPECTION DEBUGGING = TRUE);`, b.Create())
+}
+
+func TestResourceClusterCreateNoReplicas(t *testing.T) {
+	r := require.New(t)
+	d := schema.TestResourceDataRaw(t, Cluster().Schema, map[string]interface{}{
+		"name":               "cluster",
+		"size":               "xsmall",
+		"replication_factor": 0,
+	})
+	r.Equal(`CREATE CLUSTER "cluster" (SIZE = 'xsmall', REPLICATION FACTOR = 0);`, clusterBuilderFromData(d).Create())
 }
 
 func TestResourceClusterRead(t *testing.T) {
 	r := require.New(t)
 	b := newClusterBuilder("cluster")
-	r.Equal(`SELECT id, name FROM mz_clusters WHERE name = 'cluster';`, b.Read())
+	q, args := b.Read()
+	r.Equal(`
+		SELECT
+			mz_clusters.id,
+			mz_clusters.name,
+			mz_clusters.managed,
+			mz_clusters.size,
+			mz_clusters.replication_factor,
+			mz_clusters.availability_zones,
+			EXTRACT(EPOCH FROM mz_clusters.introspection_interval),
+			mz_clusters.introspection_debugging,
+			mz_roles.name,
+			mz_comments.comment
+		FROM mz_clusters JOIN mz_roles
+			ON mz_clusters.owner_id = mz_roles.id
+		LEFT JOIN mz_internal.mz_comments
+			ON mz_clusters.id = mz_comments.id
+			AND mz_comments.object_type = 'cluster'
+			AND mz_comments.object_sub_id IS NULL
+		WHERE mz_clusters.name = $1;
+	`, q)
+	r.Equal([]interface{}{"cluster"}, args)
+}
+
+func TestResourceClusterResize(t *testing.T) {
+	r := require.New(t)
+	b := newClusterBuilder("cluster")
+	r.Equal(`ALTER CLUSTER "cluster" SET (SIZE = 'small');`, b.Resize("small"))
+}
+
+func TestResourceClusterAlterReplicationFactor(t *testing.T) {
+	r := require.New(t)
+	b := newClusterBuilder("cluster")
+	r.Equal(`ALTER CLUSTER "cluster" SET (REPLICATION FACTOR = 0);`, b.AlterReplicationFactor(0))
+}
+
+func TestResourceClusterAlterAvailabilityZones(t *testing.T) {
+	r := require.New(t)
+	b := newClusterBuilder("cluster")
+	r.Equal(`ALTER CLUSTER "cluster" SET (AVAILABILITY ZONES = ('use1-az1'));`, b.AlterAvailabilityZones([]string{"use1-az1"}))
+}
+
+func TestResourceClusterAlterIntrospection(t *testing.T) {
+	r := require.New(t)
+	b := newClusterBuilder("cluster")
+	r.Equal(`ALTER CLUSTER "cluster" SET (INTROSPECTION INTERVAL = '10s');`, b.AlterIntrospectionInterval("10s"))
+	r.Equal(`ALTER CLUSTER "cluster" SET (INTROSPECTION INTERVAL = DEFAULT);`, b.AlterIntrospectionInterval(""))
+	r.Equal(`ALTER CLUSTER "cluster" SET (INTROSPECTION DEBUGGING = FALSE);`, b.AlterIntrospectionDebugging(false))
+}
+
+func TestResourceClusterReadHydration(t *testing.T) {
+	r := require.New(t)
+	b := newClusterBuilder("cluster")
+	q, args := b.ReadHydration([]string{"r1_new"})
+	r.Equal(`
+		WITH objects AS (
+			SELECT mz_indexes.id
+			FROM mz_indexes
+			JOIN mz_clusters
+				ON mz_indexes.cluster_id = mz_clusters.id
+			WHERE mz_clusters.name = $1
+			UNION ALL
+			SELECT mz_materialized_views.id
+			FROM mz_materialized_views
+			JOIN mz_clusters
+				ON mz_materialized_views.cluster_id = mz_clusters.id
+			WHERE mz_clusters.name = $1
+		)
+		SELECT
+			(SELECT count(*) FROM objects),
+			(
+				SELECT count(*)
+				FROM mz_internal.mz_hydration_statuses
+				JOIN mz_cluster_replicas
+					ON mz_hydration_statuses.replica_id = mz_cluster_replicas.id
+				JOIN mz_clusters
+					ON mz_cluster_replicas.cluster_id = mz_clusters.id
+				WHERE mz_clusters.name = $1
+				AND mz_cluster_replicas.name = ANY($2)
+				AND mz_hydration_statuses.object_id IN (SELECT id FROM objects)
+				AND mz_hydration_statuses.hydrated
+			);
+	`, q)
+	r.Equal([]interface{}{"cluster", pq.Array([]string{"r1_new"})}, args)
+}
+
+func TestResourceClusterAlterManaged(t *testing.T) {
+	r := require.New(t)
+	b := newClusterBuilder("cluster")
+	r.Equal(`ALTER CLUSTER "cluster" SET (MANAGED = false);`, b.AlterUnmanaged())
+
+	b.Size("small").ReplicationFactor(2)
+	r.Equal(`ALTER CLUSTER "cluster" SET (MANAGED, SIZE = 'small', REPLICATION FACTOR = 2);`, b.AlterManaged())
+}
+
+func TestResourceClusterReadManaged(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(`SELECT\s+mz_clusters.id`).WithArgs("cluster").WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name", "managed", "size", "replication_factor", "availability_zones", "introspection_interval", "introspection_debugging", "owner", "comment"}).
+				AddRow("u1", "cluster", true, "small", 2, "{use1-az1,use1-az2}", 0.5, true, "mz_system", nil),
+		)
+
+		d := schema.TestResourceDataRaw(t, Cluster().Schema, map[string]interface{}{"name": "cluster"})
+		diags := resourceClusterRead(context.TODO(), d, testMeta(db))
+		r.False(diags.HasError())
+		r.Equal("small", d.Get("size"))
+		r.Equal(2, d.Get("replication_factor"))
+		r.Equal([]interface{}{"use1-az1", "use1-az2"}, d.Get("availability_zones"))
+		r.Equal("500ms", d.Get("introspection_interval"))
+		r.Equal(true, d.Get("introspection_debugging"))
+	})
+}
+
+func TestResourceClusterUpdateErrorKeepsState(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.MatchExpectationsInOrder(true)
+		mock.ExpectExec(`ALTER CLUSTER "cluster" SET \(SIZE = 'small'\);`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`ALTER CLUSTER "cluster" SET \(REPLICATION FACTOR = 3\);`).WillReturnError(&pq.Error{Code: "53000", Message: "insufficient resources"})
+
+		d := UpdateData(t, Cluster(), "u1", map[string]string{
+			"id":                 "u1",
+			"name":               "cluster",
+			"size":               "xsmall",
+			"replication_factor": "2",
+			"hydration_timeout":  "10m",
+		}, map[string]interface{}{
+			"name":               "cluster",
+			"size":               "small",
+			"replication_factor": 3,
+		}, testMeta(db))
+
+		diags := resourceClusterUpdate(context.TODO(), d, testMeta(db))
+		r.True(diags.HasError())
+		r.Equal("xsmall", d.State().Attributes["size"])
+		r.Equal("2", d.State().Attributes["replication_factor"])
+	})
+}
+
+func TestResourceClusterBlueGreenResize(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.MatchExpectationsInOrder(true)
+
+		mock.ExpectQuery(`SELECT mz_cluster_replicas.name`).WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("r1"))
+		mock.ExpectExec(`ALTER CLUSTER "cluster" SET \(MANAGED = false\);`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`CREATE CLUSTER REPLICA "cluster"."r1_\w+" SIZE = 'small';`).WillReturnResult(sqlmock.NewResult(1, 1))
+		// Objects that have not reported a status on the new replica are pending
+		mock.ExpectQuery(`WITH objects AS`).WillReturnRows(sqlmock.NewRows([]string{"objects", "hydrated"}).AddRow(2, 0))
+		mock.ExpectQuery(`WITH objects AS`).WillReturnRows(sqlmock.NewRows([]string{"objects", "hydrated"}).AddRow(2, 2))
+		mock.ExpectExec(`DROP CLUSTER REPLICA "cluster"."r1";`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`ALTER CLUSTER REPLICA "cluster"."r1_\w+" RENAME TO "r1";`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`ALTER CLUSTER "cluster" SET \(MANAGED, SIZE = 'small', REPLICATION FACTOR = 1\);`).WillReturnResult(sqlmock.NewResult(1, 1))
+
+		d := schema.TestResourceDataRaw(t, Cluster().Schema, map[string]interface{}{
+			"name":               "cluster",
+			"size":               "small",
+			"replication_factor": 1,
+			"blue_green_resize":  true,
+		})
+
+		diags := resourceClusterBlueGreenResize(context.TODO(), d, testMeta(db))
+		r.False(diags.HasError())
+	})
+}
+
+func TestResourceClusterBlueGreenResizeCreateError(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.MatchExpectationsInOrder(true)
+
+		mock.ExpectQuery(`SELECT mz_cluster_replicas.name`).WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("r1").AddRow("r2"))
+		mock.ExpectExec(`ALTER CLUSTER "cluster" SET \(MANAGED = false\);`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`CREATE CLUSTER REPLICA "cluster"."r1_\w+" SIZE = 'small';`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`CREATE CLUSTER REPLICA "cluster"."r2_\w+" SIZE = 'small';`).WillReturnError(&pq.Error{Code: "53000", Message: "insufficient resources"})
+		mock.ExpectExec(`DROP CLUSTER REPLICA "cluster"."r1_\w+";`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`ALTER CLUSTER "cluster" SET \(MANAGED, SIZE = 'xsmall', REPLICATION FACTOR = 2\);`).WillReturnResult(sqlmock.NewResult(1, 1))
+
+		d := UpdateData(t, Cluster(), "u1", map[string]string{
+			"id":                 "u1",
+			"name":               "cluster",
+			"size":               "xsmall",
+			"replication_factor": "2",
+			"blue_green_resize":  "true",
+			"hydration_timeout":  "10m",
+		}, map[string]interface{}{
+			"name":               "cluster",
+			"size":               "small",
+			"replication_factor": 2,
+			"blue_green_resize":  true,
+		}, testMeta(db))
+
+		diags := resourceClusterBlueGreenResize(context.TODO(), d, testMeta(db))
+		r.True(diags.HasError())
+		r.Contains(diags[0].Summary, "insufficient resources")
+	})
+}
+
+func TestResourceClusterBlueGreenResizeExpiredRollback(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.MatchExpectationsInOrder(true)
+
+		mock.ExpectQuery(`SELECT mz_cluster_replicas.name`).WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("r1"))
+		mock.ExpectExec(`ALTER CLUSTER "cluster" SET \(MANAGED = false\);`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`CREATE CLUSTER REPLICA "cluster"."r1_\w+" SIZE = 'small' AVAILABILITY ZONE = 'use1-az2' INTROSPECTION INTERVAL = '2s';`).WillReturnResult(sqlmock.NewResult(1, 1))
+		// The update times out while waiting for the new replica to hydrate
+		mock.ExpectQuery(`WITH objects AS`).WillDelayFor(time.Second).WillReturnRows(sqlmock.NewRows([]string{"objects", "hydrated"}).AddRow(2, 0))
+		mock.ExpectExec(`DROP CLUSTER REPLICA "cluster"."r1_\w+";`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`ALTER CLUSTER "cluster" SET \(MANAGED, SIZE = 'xsmall', REPLICATION FACTOR = 1, AVAILABILITY ZONES = \('use1-az1'\), INTROSPECTION INTERVAL = '1s', INTROSPECTION DEBUGGING = TRUE\);`).WillReturnResult(sqlmock.NewResult(1, 1))
+
+		d := UpdateData(t, Cluster(), "u1", map[string]string{
+			"id":                      "u1",
+			"name":                    "cluster",
+			"size":                    "xsmall",
+			"replication_factor":      "1",
+			"availability_zones.#":    "1",
+			"availability_zones.0":    "use1-az1",
+			"introspection_interval":  "1s",
+			"introspection_debugging": "true",
+			"blue_green_resize":       "true",
+			"hydration_timeout":       "10m",
+		}, map[string]interface{}{
+			"name":                    "cluster",
+			"size":                    "small",
+			"replication_factor":      1,
+			"availability_zones":      []interface{}{"use1-az2"},
+			"introspection_interval":  "2s",
+			"introspection_debugging": false,
+			"blue_green_resize":       true,
+		}, testMeta(db))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		diags := resourceClusterBlueGreenResize(ctx, d, testMeta(db))
+		r.True(diags.HasError())
+		r.Len(diags, 1)
+	})
+}
+
+func TestResourceClusterBlueGreenResizeRequiresReplicas(t *testing.T) {
+	r := require.New(t)
+
+	state := &terraform.InstanceState{ID: "u1", Attributes: map[string]string{
+		"id":                 "u1",
+		"name":               "cluster",
+		"size":               "xsmall",
+		"replication_factor": "0",
+		"blue_green_resize":  "true",
+		"hydration_timeout":  "10m",
+	}}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":               "cluster",
+		"size":               "small",
+		"replication_factor": 0,
+		"blue_green_resize":  true,
+	})
+
+	_, err := Cluster().Diff(context.TODO(), state, config, nil)
+	r.EqualError(err, "blue_green_resize requires a replication_factor of at least 1")
+}
+
+func TestResourceClusterAlterOwner(t *testing.T) {
+	r := require.New(t)
+	b := newClusterBuilder("cluster")
+	r.Equal(`ALTER CLUSTER "cluster" OWNER TO "role";`, b.AlterOwner("role"))
+}
+
+func TestResourceClusterComment(t *testing.T) {
+	r := require.New(t)
+	b := newClusterBuilder("cluster")
+	r.Equal(`COMMENT ON CLUSTER "cluster" IS 'A comment';`, b.Comment("A comment"))
+}
+
+func TestResourceClusterCommentRemove(t *testing.T) {
+	r := require.New(t)
+	b := newClusterBuilder("cluster")
+	r.Equal(`COMMENT ON CLUSTER "cluster" IS NULL;`, b.Comment(""))
 }
 
 func TestResourceClusterDrop(t *testing.T) {
 	r := require.New(t)
 	b := newClusterBuilder("cluster")
-	r.Equal(`DROP CLUSTER cluster;`, b.Drop())
+	r.Equal(`DROP CLUSTER "cluster";`, b.Drop())
 }
@@ -0,0 +1,142 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Statements shared by every connection type. The type specific builders
+// embed ConnectionBuilder and only implement Create and Read.
+type ConnectionBuilder struct {
+	connectionName string
+	schemaName     string
+	databaseName   string
+}
+
+func newConnectionBuilder(connectionName, schemaName, databaseName string) *ConnectionBuilder {
+	return &ConnectionBuilder{
+		connectionName: connectionName,
+		schemaName:     schemaName,
+		databaseName:   databaseName,
+	}
+}
+
+func (b *ConnectionBuilder) Rename(newName string) string {
+	return fmt.Sprintf(`ALTER CONNECTION %s RENAME TO %s;`, qualifiedName(b.databaseName, b.schemaName, b.connectionName), quoteIdentifier(newName))
+}
+
+func (b *ConnectionBuilder) Drop() string {
+	return fmt.Sprintf(`DROP CONNECTION %s;`, qualifiedName(b.databaseName, b.schemaName, b.connectionName))
+}
+
+func (b *ConnectionBuilder) ShowCreate() string {
+	return fmt.Sprintf(`SHOW CREATE CONNECTION %s;`, qualifiedName(b.databaseName, b.schemaName, b.connectionName))
+}
+
+// Most connection options have no catalog column, so they are read back
+// from the statement Materialize recorded for the connection
+func readConnectionOptions(ctx context.Context, conn *ProviderMeta, b *ConnectionBuilder) (map[string]string, error) {
+	createSql, err := readCreateSql(ctx, conn, b.ShowCreate())
+	if err != nil {
+		return nil, err
+	}
+	return parseConnectionOptions(createSql), nil
+}
+
+// Parses the option list of a CREATE CONNECTION statement into a map keyed
+// by the upper case option name, e.g. HOST or SSL MODE. String literals are
+// unquoted and references to secrets and connections are returned as dotted
+// names such as database.schema.name.
+func parseConnectionOptions(createSql string) map[string]string {
+	options := map[string]string{}
+
+	var quote rune
+	depth, start := 0, 0
+	for i, c := range createSql {
+		if quote != 0 {
+			if c == quote {
+				quote = 0
+			}
+			continue
+		}
+
+		switch c {
+		case '\'', '"':
+			quote = c
+		case '(':
+			depth++
+			if depth == 1 {
+				start = i + 1
+			}
+		case ',', ')':
+			if depth == 1 {
+				if key, value := splitConnectionOption(strings.TrimSpace(createSql[start:i])); key != "" {
+					options[key] = value
+				}
+				start = i + 1
+			}
+			if c == ')' {
+				depth--
+				if depth == 0 {
+					return options
+				}
+			}
+		}
+	}
+	return options
+}
+
+// Splits an option written either as KEY = value or KEY value
+func splitConnectionOption(option string) (string, string) {
+	var key, value string
+	if i := strings.Index(option, " = "); i > 0 && !strings.ContainsAny(option[:i], `'"`) {
+		key, value = option[:i], option[i+3:]
+	} else if i := strings.IndexAny(option, `'"(0123456789`); i > 0 {
+		key, value = strings.TrimSpace(option[:i]), option[i:]
+	} else {
+		return "", ""
+	}
+
+	key = strings.TrimSuffix(key, " SECRET")
+	value = strings.TrimPrefix(strings.TrimSpace(value), "SECRET ")
+	return key, unquoteValue(value)
+}
+
+// Renames the connection. The type specific Update functions read the
+// connection back afterwards.
+func resourceConnectionUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	conn := meta.(*ProviderMeta)
+	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
+
+	if d.HasChange("name") {
+		oldName, newName := d.GetChange("name")
+
+		builder := newConnectionBuilder(oldName.(string), schemaName, databaseName)
+		q := builder.Rename(newName.(string))
+
+		if diags := ExecResource(ctx, conn, q); diags.HasError() {
+			return diags
+		}
+	}
+
+	return diags
+}
+
+func resourceConnectionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*ProviderMeta)
+	connectionName := d.Get("name").(string)
+	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
+
+	builder := newConnectionBuilder(connectionName, schemaName, databaseName)
+	q := builder.Drop()
+
+	return ExecResource(ctx, conn, q)
+}
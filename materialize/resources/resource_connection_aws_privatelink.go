@@ -0,0 +1,198 @@
+package resources
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ConnectionAwsPrivateLink() *schema.Resource {
+	return importDefaults(&schema.Resource{
+		Description: "An AWS PrivateLink connection establishes a link to an AWS PrivateLink service.",
+
+		CreateContext: resourceConnectionAwsPrivateLinkCreate,
+		ReadContext:   resourceConnectionAwsPrivateLinkRead,
+		UpdateContext: resourceConnectionAwsPrivateLinkUpdate,
+		DeleteContext: resourceConnectionDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importQualifiedName(resourceConnectionAwsPrivateLinkRead, "database_name", "schema_name", "name"),
+		},
+
+		Timeouts: defaultTimeouts(),
+
+		CustomizeDiff: defaultDatabaseName,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "The identifier for the connection.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"schema_name": {
+				Description: "The identifier for the connection schema.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "public",
+			},
+			"database_name": {
+				Description: "The identifier for the connection database. Defaults to the database of the provider configuration.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"service_name": {
+				Description: "The name of the AWS PrivateLink service.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"availability_zones": {
+				Description: "The IDs of the AWS availability zones in which the service is accessible.",
+				Type:        schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Required: true,
+				ForceNew: true,
+			},
+			"principal": {
+				Description: "The AWS principal Materialize uses to connect to the service. Allow it on the endpoint service.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	})
+}
+
+type ConnectionAwsPrivateLinkBuilder struct {
+	ConnectionBuilder
+	serviceName       string
+	availabilityZones []string
+}
+
+func newConnectionAwsPrivateLinkBuilder(connectionName, schemaName, databaseName string) *ConnectionAwsPrivateLinkBuilder {
+	return &ConnectionAwsPrivateLinkBuilder{
+		ConnectionBuilder: ConnectionBuilder{
+			connectionName: connectionName,
+			schemaName:     schemaName,
+			databaseName:   databaseName,
+		},
+	}
+}
+
+func (b *ConnectionAwsPrivateLinkBuilder) ServiceName(s string) *ConnectionAwsPrivateLinkBuilder {
+	b.serviceName = s
+	return b
+}
+
+func (b *ConnectionAwsPrivateLinkBuilder) AvailabilityZones(z []string) *ConnectionAwsPrivateLinkBuilder {
+	b.availabilityZones = z
+	return b
+}
+
+func (b *ConnectionAwsPrivateLinkBuilder) Create() string {
+	return fmt.Sprintf(`CREATE CONNECTION %s TO AWS PRIVATELINK (SERVICE NAME %s, AVAILABILITY ZONES (%s));`, qualifiedName(b.databaseName, b.schemaName, b.connectionName), quoteString(b.serviceName), quoteStrings(b.availabilityZones))
+}
+
+func (b *ConnectionAwsPrivateLinkBuilder) Read() (string, []interface{}) {
+	return `
+		SELECT
+			mz_connections.id,
+			mz_connections.name,
+			mz_schemas.name,
+			mz_databases.name,
+			mz_aws_privatelink_connections.principal
+		FROM mz_connections
+		JOIN mz_schemas
+			ON mz_connections.schema_id = mz_schemas.id
+		JOIN mz_databases
+			ON mz_schemas.database_id = mz_databases.id
+		JOIN mz_aws_privatelink_connections
+			ON mz_connections.id = mz_aws_privatelink_connections.id
+		WHERE mz_connections.name = $1
+		AND mz_schemas.name = $2
+		AND mz_databases.name = $3;
+	`, []interface{}{b.connectionName, b.schemaName, b.databaseName}
+}
+
+func resourceConnectionAwsPrivateLinkRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	conn := meta.(*ProviderMeta)
+	connectionName := d.Get("name").(string)
+	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
+
+	builder := newConnectionAwsPrivateLinkBuilder(connectionName, schemaName, databaseName)
+	q, args := builder.Read()
+
+	var id, name, schema, database, principal string
+	if err := queryRow(ctx, conn, q, args, &id, &name, &schema, &database, &principal); err == sql.ErrNoRows {
+		log.Printf("[WARN] connection (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return diags
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id)
+	d.Set("name", name)
+	d.Set("schema_name", schema)
+	d.Set("database_name", database)
+	d.Set("principal", principal)
+
+	options, err := readConnectionOptions(ctx, conn, &builder.ConnectionBuilder)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var zones []string
+	for _, z := range splitList(options["AVAILABILITY ZONES"]) {
+		zones = append(zones, unquoteValue(z))
+	}
+
+	d.Set("service_name", options["SERVICE NAME"])
+	d.Set("availability_zones", zones)
+
+	return diags
+}
+
+func resourceConnectionAwsPrivateLinkCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*ProviderMeta)
+
+	connectionName := d.Get("name").(string)
+	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
+
+	builder := newConnectionAwsPrivateLinkBuilder(connectionName, schemaName, databaseName)
+
+	if v, ok := d.GetOk("service_name"); ok {
+		builder.ServiceName(v.(string))
+	}
+
+	if v, ok := d.GetOk("availability_zones"); ok {
+		builder.AvailabilityZones(sliceOfStrings(v))
+	}
+
+	q := builder.Create()
+
+	if diags := ExecResource(ctx, conn, q); diags.HasError() {
+		return diags
+	}
+	return resourceConnectionAwsPrivateLinkRead(ctx, d, meta)
+}
+
+func resourceConnectionAwsPrivateLinkUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := resourceConnectionUpdate(ctx, d, meta); diags.HasError() {
+		return diags
+	}
+
+	return resourceConnectionAwsPrivateLinkRead(ctx, d, meta)
+}
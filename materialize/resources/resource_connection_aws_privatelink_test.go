@@ -0,0 +1,68 @@
+package resources
+
+import (
+	"database/sql"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceConnectionAwsPrivateLinkCreate(t *testing.T) {
+	r := require.New(t)
+	b := newConnectionAwsPrivateLinkBuilder("privatelink_conn", "schema", "database")
+	b.ServiceName("com.amazonaws.us-east-1.materialize.example")
+	b.AvailabilityZones([]string{"use1-az1", "use1-az2"})
+	r.Equal(`CREATE CONNECTION "database"."schema"."privatelink_conn" TO AWS PRIVATELINK (SERVICE NAME 'com.amazonaws.us-east-1.materialize.example', AVAILABILITY ZONES ('use1-az1', 'use1-az2'));`, b.Create())
+}
+
+func TestResourceConnectionAwsPrivateLinkRead(t *testing.T) {
+	r := require.New(t)
+	b := newConnectionAwsPrivateLinkBuilder("privatelink_conn", "schema", "database")
+	q, args := b.Read()
+	r.Equal(`
+		SELECT
+			mz_connections.id,
+			mz_connections.name,
+			mz_schemas.name,
+			mz_databases.name,
+			mz_aws_privatelink_connections.principal
+		FROM mz_connections
+		JOIN mz_schemas
+			ON mz_connections.schema_id = mz_schemas.id
+		JOIN mz_databases
+			ON mz_schemas.database_id = mz_databases.id
+		JOIN mz_aws_privatelink_connections
+			ON mz_connections.id = mz_aws_privatelink_connections.id
+		WHERE mz_connections.name = $1
+		AND mz_schemas.name = $2
+		AND mz_databases.name = $3;
+	`, q)
+	r.Equal([]interface{}{"privatelink_conn", "schema", "database"}, args)
+}
+
+func TestResourceConnectionAwsPrivateLinkRename(t *testing.T) {
+	r := require.New(t)
+	b := newConnectionAwsPrivateLinkBuilder("privatelink_conn", "schema", "database")
+	r.Equal(`ALTER CONNECTION "database"."schema"."privatelink_conn" RENAME TO "new_conn";`, b.Rename("new_conn"))
+}
+
+func TestResourceConnectionAwsPrivateLinkImport(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(`SELECT\s+mz_connections.id`).WithArgs("privatelink_conn", "public", "materialize").WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name", "schema", "database", "principal"}).AddRow("u1", "privatelink_conn", "public", "materialize", "arn:aws:iam::123456789000:role/mz_u1"),
+		)
+		mock.ExpectQuery(`SHOW CREATE CONNECTION "materialize"."public"."privatelink_conn"`).WillReturnRows(
+			sqlmock.NewRows([]string{"name", "create_sql"}).AddRow("materialize.public.privatelink_conn", `CREATE CONNECTION "materialize"."public"."privatelink_conn" TO AWS PRIVATELINK (SERVICE NAME = 'com.amazonaws.vpce.us-east-1.vpce-svc-0123', AVAILABILITY ZONES = ('use1-az1', 'use1-az2'))`),
+		)
+
+		diff := ImportPlan(t, ConnectionAwsPrivateLink(), "materialize.public.privatelink_conn", map[string]interface{}{
+			"name":               "privatelink_conn",
+			"service_name":       "com.amazonaws.vpce.us-east-1.vpce-svc-0123",
+			"availability_zones": []interface{}{"use1-az1", "use1-az2"},
+		}, testMeta(db))
+		r.True(diff.Empty(), "unexpected diff: %v", diff)
+	})
+}
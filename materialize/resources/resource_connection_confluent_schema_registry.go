@@ -0,0 +1,331 @@
+package resources
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ConnectionConfluentSchemaRegistry() *schema.Resource {
+	return importDefaults(&schema.Resource{
+		Description: "A Confluent Schema Registry connection establishes a link to a Confluent Schema Registry server.",
+
+		CreateContext: resourceConnectionConfluentSchemaRegistryCreate,
+		ReadContext:   resourceConnectionConfluentSchemaRegistryRead,
+		UpdateContext: resourceConnectionConfluentSchemaRegistryUpdate,
+		DeleteContext: resourceConnectionDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importQualifiedName(resourceConnectionConfluentSchemaRegistryRead, "database_name", "schema_name", "name"),
+		},
+
+		Timeouts: defaultTimeouts(),
+
+		CustomizeDiff: defaultDatabaseName,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "The identifier for the connection.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"schema_name": {
+				Description: "The identifier for the connection schema.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "public",
+			},
+			"database_name": {
+				Description: "The identifier for the connection database. Defaults to the database of the provider configuration.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"url": {
+				Description: "The URL of the Confluent Schema Registry.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"username": {
+				Description: "The username for the Confluent Schema Registry.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"password": {
+				Description:      "The name of the secret containing the password for the Confluent Schema Registry.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressQualifiedNameDiff,
+				ForceNew:         true,
+				RequiredWith:     []string{"username", "password"},
+			},
+			"ssl_certificate_authority": {
+				Description:      "The name of the secret containing the certificate authority used to validate the server's TLS certificate.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressQualifiedNameDiff,
+				ForceNew:         true,
+			},
+			"ssl_certificate": {
+				Description:      "The name of the secret containing the client's TLS certificate.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressQualifiedNameDiff,
+				ForceNew:         true,
+				RequiredWith:     []string{"ssl_certificate", "ssl_key"},
+			},
+			"ssl_key": {
+				Description:      "The name of the secret containing the client's TLS private key.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressQualifiedNameDiff,
+				ForceNew:         true,
+				RequiredWith:     []string{"ssl_certificate", "ssl_key"},
+			},
+			"ssh_tunnel": {
+				Description:      "The name of an SSH tunnel connection to route network traffic through.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressQualifiedNameDiff,
+				ForceNew:         true,
+				ConflictsWith:    []string{"aws_privatelink"},
+			},
+			"aws_privatelink": {
+				Description:      "The name of an AWS PrivateLink connection to route network traffic through.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressQualifiedNameDiff,
+				ForceNew:         true,
+				ConflictsWith:    []string{"ssh_tunnel"},
+			},
+		},
+	})
+}
+
+type ConnectionConfluentSchemaRegistryBuilder struct {
+	ConnectionBuilder
+	url                     string
+	username                string
+	password                string
+	sslCertificateAuthority string
+	sslCertificate          string
+	sslKey                  string
+	sshTunnel               string
+	awsPrivateLink          string
+}
+
+func newConnectionConfluentSchemaRegistryBuilder(connectionName, schemaName, databaseName string) *ConnectionConfluentSchemaRegistryBuilder {
+	return &ConnectionConfluentSchemaRegistryBuilder{
+		ConnectionBuilder: ConnectionBuilder{
+			connectionName: connectionName,
+			schemaName:     schemaName,
+			databaseName:   databaseName,
+		},
+	}
+}
+
+func (b *ConnectionConfluentSchemaRegistryBuilder) Url(u string) *ConnectionConfluentSchemaRegistryBuilder {
+	b.url = u
+	return b
+}
+
+func (b *ConnectionConfluentSchemaRegistryBuilder) Username(u string) *ConnectionConfluentSchemaRegistryBuilder {
+	b.username = u
+	return b
+}
+
+func (b *ConnectionConfluentSchemaRegistryBuilder) Password(p string) *ConnectionConfluentSchemaRegistryBuilder {
+	b.password = p
+	return b
+}
+
+func (b *ConnectionConfluentSchemaRegistryBuilder) SslCertificateAuthority(s string) *ConnectionConfluentSchemaRegistryBuilder {
+	b.sslCertificateAuthority = s
+	return b
+}
+
+func (b *ConnectionConfluentSchemaRegistryBuilder) SslCertificate(s string) *ConnectionConfluentSchemaRegistryBuilder {
+	b.sslCertificate = s
+	return b
+}
+
+func (b *ConnectionConfluentSchemaRegistryBuilder) SslKey(s string) *ConnectionConfluentSchemaRegistryBuilder {
+	b.sslKey = s
+	return b
+}
+
+func (b *ConnectionConfluentSchemaRegistryBuilder) SshTunnel(s string) *ConnectionConfluentSchemaRegistryBuilder {
+	b.sshTunnel = s
+	return b
+}
+
+func (b *ConnectionConfluentSchemaRegistryBuilder) AwsPrivateLink(a string) *ConnectionConfluentSchemaRegistryBuilder {
+	b.awsPrivateLink = a
+	return b
+}
+
+func (b *ConnectionConfluentSchemaRegistryBuilder) Create() string {
+	q := strings.Builder{}
+	q.WriteString(fmt.Sprintf(`CREATE CONNECTION %s TO CONFLUENT SCHEMA REGISTRY`, qualifiedName(b.databaseName, b.schemaName, b.connectionName)))
+
+	var p []string
+	p = append(p, fmt.Sprintf(`URL %s`, quoteString(b.url)))
+
+	if b.username != "" {
+		p = append(p, fmt.Sprintf(`USERNAME = %s`, quoteString(b.username)))
+	}
+
+	if b.password != "" {
+		p = append(p, fmt.Sprintf(`PASSWORD = SECRET %s`, quoteReference(b.password)))
+	}
+
+	if b.sslCertificateAuthority != "" {
+		p = append(p, fmt.Sprintf(`SSL CERTIFICATE AUTHORITY = SECRET %s`, quoteReference(b.sslCertificateAuthority)))
+	}
+
+	if b.sslCertificate != "" {
+		p = append(p, fmt.Sprintf(`SSL CERTIFICATE = SECRET %s`, quoteReference(b.sslCertificate)))
+	}
+
+	if b.sslKey != "" {
+		p = append(p, fmt.Sprintf(`SSL KEY = SECRET %s`, quoteReference(b.sslKey)))
+	}
+
+	if b.sshTunnel != "" {
+		p = append(p, fmt.Sprintf(`SSH TUNNEL %s`, quoteReference(b.sshTunnel)))
+	}
+
+	if b.awsPrivateLink != "" {
+		p = append(p, fmt.Sprintf(`AWS PRIVATELINK %s`, quoteReference(b.awsPrivateLink)))
+	}
+
+	q.WriteString(fmt.Sprintf(` (%s);`, strings.Join(p[:], ", ")))
+	return q.String()
+}
+
+func (b *ConnectionConfluentSchemaRegistryBuilder) Read() (string, []interface{}) {
+	return `
+		SELECT
+			mz_connections.id,
+			mz_connections.name,
+			mz_schemas.name,
+			mz_databases.name
+		FROM mz_connections
+		JOIN mz_schemas
+			ON mz_connections.schema_id = mz_schemas.id
+		JOIN mz_databases
+			ON mz_schemas.database_id = mz_databases.id
+		WHERE mz_connections.name = $1
+		AND mz_schemas.name = $2
+		AND mz_databases.name = $3
+		AND mz_connections.type = 'confluent-schema-registry';
+	`, []interface{}{b.connectionName, b.schemaName, b.databaseName}
+}
+
+func resourceConnectionConfluentSchemaRegistryRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	conn := meta.(*ProviderMeta)
+	connectionName := d.Get("name").(string)
+	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
+
+	builder := newConnectionConfluentSchemaRegistryBuilder(connectionName, schemaName, databaseName)
+	q, args := builder.Read()
+
+	var id, name, schema, database string
+	if err := queryRow(ctx, conn, q, args, &id, &name, &schema, &database); err == sql.ErrNoRows {
+		log.Printf("[WARN] connection (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return diags
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id)
+	d.Set("name", name)
+	d.Set("schema_name", schema)
+	d.Set("database_name", database)
+
+	options, err := readConnectionOptions(ctx, conn, &builder.ConnectionBuilder)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("url", options["URL"])
+	d.Set("username", options["USERNAME"])
+	d.Set("password", options["PASSWORD"])
+	d.Set("ssl_certificate_authority", options["SSL CERTIFICATE AUTHORITY"])
+	d.Set("ssl_certificate", options["SSL CERTIFICATE"])
+	d.Set("ssl_key", options["SSL KEY"])
+	d.Set("ssh_tunnel", options["SSH TUNNEL"])
+	d.Set("aws_privatelink", options["AWS PRIVATELINK"])
+
+	return diags
+}
+
+func resourceConnectionConfluentSchemaRegistryCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*ProviderMeta)
+
+	connectionName := d.Get("name").(string)
+	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
+
+	builder := newConnectionConfluentSchemaRegistryBuilder(connectionName, schemaName, databaseName)
+
+	if v, ok := d.GetOk("url"); ok {
+		builder.Url(v.(string))
+	}
+
+	if v, ok := d.GetOk("username"); ok {
+		builder.Username(v.(string))
+	}
+
+	if v, ok := d.GetOk("password"); ok {
+		builder.Password(v.(string))
+	}
+
+	if v, ok := d.GetOk("ssl_certificate_authority"); ok {
+		builder.SslCertificateAuthority(v.(string))
+	}
+
+	if v, ok := d.GetOk("ssl_certificate"); ok {
+		builder.SslCertificate(v.(string))
+	}
+
+	if v, ok := d.GetOk("ssl_key"); ok {
+		builder.SslKey(v.(string))
+	}
+
+	if v, ok := d.GetOk("ssh_tunnel"); ok {
+		builder.SshTunnel(v.(string))
+	}
+
+	if v, ok := d.GetOk("aws_privatelink"); ok {
+		builder.AwsPrivateLink(v.(string))
+	}
+
+	q := builder.Create()
+
+	if diags := ExecResource(ctx, conn, q); diags.HasError() {
+		return diags
+	}
+	return resourceConnectionConfluentSchemaRegistryRead(ctx, d, meta)
+}
+
+func resourceConnectionConfluentSchemaRegistryUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := resourceConnectionUpdate(ctx, d, meta); diags.HasError() {
+		return diags
+	}
+
+	return resourceConnectionConfluentSchemaRegistryRead(ctx, d, meta)
+}
@@ -0,0 +1,98 @@
+package resources
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceConnectionConfluentSchemaRegistryCreate(t *testing.T) {
+	r := require.New(t)
+	b := newConnectionConfluentSchemaRegistryBuilder("csr_conn", "schema", "database")
+	b.Url("http://localhost:8081")
+	b.Username("user")
+	b.Password("schema.password")
+	r.Equal(`CREATE CONNECTION "database"."schema"."csr_conn" TO CONFLUENT SCHEMA REGISTRY (URL 'http://localhost:8081', USERNAME = 'user', PASSWORD = SECRET "schema"."password");`, b.Create())
+}
+
+func TestResourceConnectionConfluentSchemaRegistryCreateSsl(t *testing.T) {
+	r := require.New(t)
+	b := newConnectionConfluentSchemaRegistryBuilder("csr_conn", "schema", "database")
+	b.Url("https://localhost:8081")
+	b.SslCertificateAuthority("schema.ca")
+	b.SslCertificate("schema.cert")
+	b.SslKey("schema.key")
+	r.Equal(`CREATE CONNECTION "database"."schema"."csr_conn" TO CONFLUENT SCHEMA REGISTRY (URL 'https://localhost:8081', SSL CERTIFICATE AUTHORITY = SECRET "schema"."ca", SSL CERTIFICATE = SECRET "schema"."cert", SSL KEY = SECRET "schema"."key");`, b.Create())
+}
+
+func TestResourceConnectionConfluentSchemaRegistryCreateSshTunnel(t *testing.T) {
+	r := require.New(t)
+	b := newConnectionConfluentSchemaRegistryBuilder("csr_conn", "schema", "database")
+	b.Url("http://localhost:8081")
+	b.SshTunnel("schema.ssh_conn")
+	r.Equal(`CREATE CONNECTION "database"."schema"."csr_conn" TO CONFLUENT SCHEMA REGISTRY (URL 'http://localhost:8081', SSH TUNNEL "schema"."ssh_conn");`, b.Create())
+}
+
+func TestResourceConnectionConfluentSchemaRegistryCreateAwsPrivateLink(t *testing.T) {
+	r := require.New(t)
+	b := newConnectionConfluentSchemaRegistryBuilder("csr_conn", "schema", "database")
+	b.Url("http://localhost:8081")
+	b.AwsPrivateLink("schema.privatelink_conn")
+	r.Equal(`CREATE CONNECTION "database"."schema"."csr_conn" TO CONFLUENT SCHEMA REGISTRY (URL 'http://localhost:8081', AWS PRIVATELINK "schema"."privatelink_conn");`, b.Create())
+}
+
+func TestResourceConnectionConfluentSchemaRegistryRead(t *testing.T) {
+	r := require.New(t)
+	b := newConnectionConfluentSchemaRegistryBuilder("csr_conn", "schema", "database")
+	q, args := b.Read()
+	r.Equal(`
+		SELECT
+			mz_connections.id,
+			mz_connections.name,
+			mz_schemas.name,
+			mz_databases.name
+		FROM mz_connections
+		JOIN mz_schemas
+			ON mz_connections.schema_id = mz_schemas.id
+		JOIN mz_databases
+			ON mz_schemas.database_id = mz_databases.id
+		WHERE mz_connections.name = $1
+		AND mz_schemas.name = $2
+		AND mz_databases.name = $3
+		AND mz_connections.type = 'confluent-schema-registry';
+	`, q)
+	r.Equal([]interface{}{"csr_conn", "schema", "database"}, args)
+}
+
+func TestResourceConnectionConfluentSchemaRegistryRename(t *testing.T) {
+	r := require.New(t)
+	b := newConnectionConfluentSchemaRegistryBuilder("csr_conn", "schema", "database")
+	r.Equal(`ALTER CONNECTION "database"."schema"."csr_conn" RENAME TO "new_conn";`, b.Rename("new_conn"))
+}
+
+func TestResourceConnectionConfluentSchemaRegistryReadOptions(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(`SELECT\s+mz_connections.id`).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name", "schema", "database"}).AddRow("u1", "csr_conn", "public", "materialize"),
+		)
+		mock.ExpectQuery(`SHOW CREATE CONNECTION "materialize"."public"."csr_conn"`).WillReturnRows(
+			sqlmock.NewRows([]string{"name", "create_sql"}).AddRow("materialize.public.csr_conn", `CREATE CONNECTION "materialize"."public"."csr_conn" TO CONFLUENT SCHEMA REGISTRY (URL = 'http://localhost:8081', USERNAME = 'user', PASSWORD = SECRET "materialize"."public"."password", AWS PRIVATELINK = "materialize"."public"."privatelink")`),
+		)
+
+		d := schema.TestResourceDataRaw(t, ConnectionConfluentSchemaRegistry().Schema, map[string]interface{}{"name": "csr_conn", "database_name": "materialize"})
+		diags := resourceConnectionConfluentSchemaRegistryRead(context.TODO(), d, testMeta(db))
+		r.False(diags.HasError())
+		r.Equal("u1", d.Id())
+		r.Equal("http://localhost:8081", d.Get("url"))
+		r.Equal("user", d.Get("username"))
+		r.Equal("materialize.public.password", d.Get("password"))
+		r.Equal("materialize.public.privatelink", d.Get("aws_privatelink"))
+		r.Equal("", d.Get("ssl_certificate"))
+	})
+}
@@ -0,0 +1,372 @@
+package resources
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+func ConnectionKafka() *schema.Resource {
+	return importDefaults(&schema.Resource{
+		Description: "A Kafka connection establishes a link to a Kafka cluster.",
+
+		CreateContext: resourceConnectionKafkaCreate,
+		ReadContext:   resourceConnectionKafkaRead,
+		UpdateContext: resourceConnectionKafkaUpdate,
+		DeleteContext: resourceConnectionDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importQualifiedName(resourceConnectionKafkaRead, "database_name", "schema_name", "name"),
+		},
+
+		Timeouts: defaultTimeouts(),
+
+		CustomizeDiff: defaultDatabaseName,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "The identifier for the connection.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"schema_name": {
+				Description: "The identifier for the connection schema.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "public",
+			},
+			"database_name": {
+				Description: "The identifier for the connection database. Defaults to the database of the provider configuration.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"kafka_brokers": {
+				Description: "The Kafka brokers, as host:port pairs.",
+				Type:        schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Required: true,
+				ForceNew: true,
+			},
+			"aws_privatelink": {
+				Description:      "The name of an AWS PrivateLink connection to route traffic to the brokers through.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressQualifiedNameDiff,
+				ForceNew:         true,
+			},
+			"progress_topic": {
+				Description: "The name of a topic that Kafka sinks can use to track internal consistency metadata. If not specified, Materialize generates one.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"ssl_certificate_authority": {
+				Description:      "The name of the secret containing the certificate authority used to validate the brokers' TLS certificates.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressQualifiedNameDiff,
+				ForceNew:         true,
+			},
+			"ssl_certificate": {
+				Description:      "The name of the secret containing the client's TLS certificate.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressQualifiedNameDiff,
+				ForceNew:         true,
+				RequiredWith:     []string{"ssl_certificate", "ssl_key"},
+			},
+			"ssl_key": {
+				Description:      "The name of the secret containing the client's TLS private key.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressQualifiedNameDiff,
+				ForceNew:         true,
+				RequiredWith:     []string{"ssl_certificate", "ssl_key"},
+			},
+			"sasl_mechanisms": {
+				Description:      "The SASL mechanism to use for authentication.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressCaseDiff,
+				ForceNew:         true,
+				ValidateFunc:     validation.StringInSlice(saslMechanisms, true),
+				RequiredWith:     []string{"sasl_mechanisms", "sasl_username", "sasl_password"},
+			},
+			"sasl_username": {
+				Description:  "The SASL username.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"sasl_mechanisms", "sasl_username", "sasl_password"},
+			},
+			"sasl_password": {
+				Description:      "The name of the secret containing the SASL password.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressQualifiedNameDiff,
+				ForceNew:         true,
+				RequiredWith:     []string{"sasl_mechanisms", "sasl_username", "sasl_password"},
+			},
+		},
+	})
+}
+
+type ConnectionKafkaBuilder struct {
+	ConnectionBuilder
+	kafkaBrokers            []string
+	awsPrivateLink          string
+	progressTopic           string
+	sslCertificateAuthority string
+	sslCertificate          string
+	sslKey                  string
+	saslMechanisms          string
+	saslUsername            string
+	saslPassword            string
+}
+
+func newConnectionKafkaBuilder(connectionName, schemaName, databaseName string) *ConnectionKafkaBuilder {
+	return &ConnectionKafkaBuilder{
+		ConnectionBuilder: ConnectionBuilder{
+			connectionName: connectionName,
+			schemaName:     schemaName,
+			databaseName:   databaseName,
+		},
+	}
+}
+
+func (b *ConnectionKafkaBuilder) KafkaBrokers(k []string) *ConnectionKafkaBuilder {
+	b.kafkaBrokers = k
+	return b
+}
+
+func (b *ConnectionKafkaBuilder) AwsPrivateLink(a string) *ConnectionKafkaBuilder {
+	b.awsPrivateLink = a
+	return b
+}
+
+func (b *ConnectionKafkaBuilder) ProgressTopic(p string) *ConnectionKafkaBuilder {
+	b.progressTopic = p
+	return b
+}
+
+func (b *ConnectionKafkaBuilder) SslCertificateAuthority(s string) *ConnectionKafkaBuilder {
+	b.sslCertificateAuthority = s
+	return b
+}
+
+func (b *ConnectionKafkaBuilder) SslCertificate(s string) *ConnectionKafkaBuilder {
+	b.sslCertificate = s
+	return b
+}
+
+func (b *ConnectionKafkaBuilder) SslKey(s string) *ConnectionKafkaBuilder {
+	b.sslKey = s
+	return b
+}
+
+func (b *ConnectionKafkaBuilder) SaslMechanisms(s string) *ConnectionKafkaBuilder {
+	b.saslMechanisms = s
+	return b
+}
+
+func (b *ConnectionKafkaBuilder) SaslUsername(s string) *ConnectionKafkaBuilder {
+	b.saslUsername = s
+	return b
+}
+
+func (b *ConnectionKafkaBuilder) SaslPassword(s string) *ConnectionKafkaBuilder {
+	b.saslPassword = s
+	return b
+}
+
+func (b *ConnectionKafkaBuilder) Create() string {
+	q := strings.Builder{}
+	q.WriteString(fmt.Sprintf(`CREATE CONNECTION %s TO KAFKA`, qualifiedName(b.databaseName, b.schemaName, b.connectionName)))
+
+	var brokers []string
+	for _, broker := range b.kafkaBrokers {
+		if b.awsPrivateLink != "" {
+			brokers = append(brokers, fmt.Sprintf(`%s USING AWS PRIVATELINK %s`, quoteString(broker), quoteReference(b.awsPrivateLink)))
+		} else {
+			brokers = append(brokers, quoteString(broker))
+		}
+	}
+
+	var p []string
+	p = append(p, fmt.Sprintf(`BROKERS (%s)`, strings.Join(brokers[:], ", ")))
+
+	if b.progressTopic != "" {
+		p = append(p, fmt.Sprintf(`PROGRESS TOPIC %s`, quoteString(b.progressTopic)))
+	}
+
+	if b.sslCertificateAuthority != "" {
+		p = append(p, fmt.Sprintf(`SSL CERTIFICATE AUTHORITY = SECRET %s`, quoteReference(b.sslCertificateAuthority)))
+	}
+
+	if b.sslCertificate != "" {
+		p = append(p, fmt.Sprintf(`SSL CERTIFICATE = SECRET %s`, quoteReference(b.sslCertificate)))
+	}
+
+	if b.sslKey != "" {
+		p = append(p, fmt.Sprintf(`SSL KEY = SECRET %s`, quoteReference(b.sslKey)))
+	}
+
+	if b.saslMechanisms != "" {
+		p = append(p, fmt.Sprintf(`SASL MECHANISMS = %s`, quoteString(strings.ToUpper(b.saslMechanisms))))
+	}
+
+	if b.saslUsername != "" {
+		p = append(p, fmt.Sprintf(`SASL USERNAME = %s`, quoteString(b.saslUsername)))
+	}
+
+	if b.saslPassword != "" {
+		p = append(p, fmt.Sprintf(`SASL PASSWORD = SECRET %s`, quoteReference(b.saslPassword)))
+	}
+
+	q.WriteString(fmt.Sprintf(` (%s);`, strings.Join(p[:], ", ")))
+	return q.String()
+}
+
+func (b *ConnectionKafkaBuilder) Read() (string, []interface{}) {
+	return `
+		SELECT
+			mz_connections.id,
+			mz_connections.name,
+			mz_schemas.name,
+			mz_databases.name,
+			mz_kafka_connections.brokers,
+			mz_kafka_connections.sink_progress_topic
+		FROM mz_connections
+		JOIN mz_schemas
+			ON mz_connections.schema_id = mz_schemas.id
+		JOIN mz_databases
+			ON mz_schemas.database_id = mz_databases.id
+		JOIN mz_kafka_connections
+			ON mz_connections.id = mz_kafka_connections.id
+		WHERE mz_connections.name = $1
+		AND mz_schemas.name = $2
+		AND mz_databases.name = $3;
+	`, []interface{}{b.connectionName, b.schemaName, b.databaseName}
+}
+
+func resourceConnectionKafkaRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	conn := meta.(*ProviderMeta)
+	connectionName := d.Get("name").(string)
+	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
+
+	builder := newConnectionKafkaBuilder(connectionName, schemaName, databaseName)
+	q, args := builder.Read()
+
+	var id, name, schema, database string
+	var brokers []string
+	var progressTopic sql.NullString
+	if err := queryRow(ctx, conn, q, args, &id, &name, &schema, &database, pq.Array(&brokers), &progressTopic); err == sql.ErrNoRows {
+		log.Printf("[WARN] connection (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return diags
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id)
+	d.Set("name", name)
+	d.Set("schema_name", schema)
+	d.Set("database_name", database)
+	d.Set("kafka_brokers", brokers)
+	d.Set("progress_topic", progressTopic.String)
+
+	createSql, err := readCreateSql(ctx, conn, builder.ShowCreate())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	options := parseConnectionOptions(createSql)
+	d.Set("ssl_certificate_authority", options["SSL CERTIFICATE AUTHORITY"])
+	d.Set("ssl_certificate", options["SSL CERTIFICATE"])
+	d.Set("ssl_key", options["SSL KEY"])
+	d.Set("sasl_mechanisms", options["SASL MECHANISMS"])
+	d.Set("sasl_username", options["SASL USERNAME"])
+	d.Set("sasl_password", options["SASL PASSWORD"])
+
+	// PrivateLink is set on each broker rather than as a connection option
+	d.Set("aws_privatelink", createSqlValue(createSql, "USING AWS PRIVATELINK"))
+
+	return diags
+}
+
+func resourceConnectionKafkaCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*ProviderMeta)
+
+	connectionName := d.Get("name").(string)
+	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
+
+	builder := newConnectionKafkaBuilder(connectionName, schemaName, databaseName)
+
+	if v, ok := d.GetOk("kafka_brokers"); ok {
+		builder.KafkaBrokers(sliceOfStrings(v))
+	}
+
+	if v, ok := d.GetOk("aws_privatelink"); ok {
+		builder.AwsPrivateLink(v.(string))
+	}
+
+	if v, ok := d.GetOk("progress_topic"); ok {
+		builder.ProgressTopic(v.(string))
+	}
+
+	if v, ok := d.GetOk("ssl_certificate_authority"); ok {
+		builder.SslCertificateAuthority(v.(string))
+	}
+
+	if v, ok := d.GetOk("ssl_certificate"); ok {
+		builder.SslCertificate(v.(string))
+	}
+
+	if v, ok := d.GetOk("ssl_key"); ok {
+		builder.SslKey(v.(string))
+	}
+
+	if v, ok := d.GetOk("sasl_mechanisms"); ok {
+		builder.SaslMechanisms(v.(string))
+	}
+
+	if v, ok := d.GetOk("sasl_username"); ok {
+		builder.SaslUsername(v.(string))
+	}
+
+	if v, ok := d.GetOk("sasl_password"); ok {
+		builder.SaslPassword(v.(string))
+	}
+
+	q := builder.Create()
+
+	if diags := ExecResource(ctx, conn, q); diags.HasError() {
+		return diags
+	}
+	return resourceConnectionKafkaRead(ctx, d, meta)
+}
+
+func resourceConnectionKafkaUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := resourceConnectionUpdate(ctx, d, meta); diags.HasError() {
+		return diags
+	}
+
+	return resourceConnectionKafkaRead(ctx, d, meta)
+}
@@ -0,0 +1,109 @@
+package resources
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceConnectionKafkaCreate(t *testing.T) {
+	r := require.New(t)
+	b := newConnectionKafkaBuilder("kafka_conn", "schema", "database")
+	b.KafkaBrokers([]string{"localhost:9092", "localhost:9093"})
+	b.ProgressTopic("topic")
+	r.Equal(`CREATE CONNECTION "database"."schema"."kafka_conn" TO KAFKA (BROKERS ('localhost:9092', 'localhost:9093'), PROGRESS TOPIC 'topic');`, b.Create())
+}
+
+func TestResourceConnectionKafkaCreateSsl(t *testing.T) {
+	r := require.New(t)
+	b := newConnectionKafkaBuilder("kafka_conn", "schema", "database")
+	b.KafkaBrokers([]string{"localhost:9092"})
+	b.SslCertificateAuthority("schema.ca")
+	b.SslCertificate("schema.cert")
+	b.SslKey("schema.key")
+	r.Equal(`CREATE CONNECTION "database"."schema"."kafka_conn" TO KAFKA (BROKERS ('localhost:9092'), SSL CERTIFICATE AUTHORITY = SECRET "schema"."ca", SSL CERTIFICATE = SECRET "schema"."cert", SSL KEY = SECRET "schema"."key");`, b.Create())
+}
+
+func TestResourceConnectionKafkaCreateSasl(t *testing.T) {
+	r := require.New(t)
+	b := newConnectionKafkaBuilder("kafka_conn", "schema", "database")
+	b.KafkaBrokers([]string{"localhost:9092"})
+	b.SaslMechanisms("scram-sha-256")
+	b.SaslUsername("user")
+	b.SaslPassword("schema.password")
+	r.Equal(`CREATE CONNECTION "database"."schema"."kafka_conn" TO KAFKA (BROKERS ('localhost:9092'), SASL MECHANISMS = 'SCRAM-SHA-256', SASL USERNAME = 'user', SASL PASSWORD = SECRET "schema"."password");`, b.Create())
+}
+
+func TestResourceConnectionKafkaCreateAwsPrivateLink(t *testing.T) {
+	r := require.New(t)
+	b := newConnectionKafkaBuilder("kafka_conn", "schema", "database")
+	b.KafkaBrokers([]string{"b-1.hostname-1:9096", "b-2.hostname-2:9096"})
+	b.AwsPrivateLink("schema.privatelink_conn")
+	r.Equal(`CREATE CONNECTION "database"."schema"."kafka_conn" TO KAFKA (BROKERS ('b-1.hostname-1:9096' USING AWS PRIVATELINK "schema"."privatelink_conn", 'b-2.hostname-2:9096' USING AWS PRIVATELINK "schema"."privatelink_conn"));`, b.Create())
+}
+
+func TestResourceConnectionKafkaRead(t *testing.T) {
+	r := require.New(t)
+	b := newConnectionKafkaBuilder("kafka_conn", "schema", "database")
+	q, args := b.Read()
+	r.Equal(`
+		SELECT
+			mz_connections.id,
+			mz_connections.name,
+			mz_schemas.name,
+			mz_databases.name,
+			mz_kafka_connections.brokers,
+			mz_kafka_connections.sink_progress_topic
+		FROM mz_connections
+		JOIN mz_schemas
+			ON mz_connections.schema_id = mz_schemas.id
+		JOIN mz_databases
+			ON mz_schemas.database_id = mz_databases.id
+		JOIN mz_kafka_connections
+			ON mz_connections.id = mz_kafka_connections.id
+		WHERE mz_connections.name = $1
+		AND mz_schemas.name = $2
+		AND mz_databases.name = $3;
+	`, q)
+	r.Equal([]interface{}{"kafka_conn", "schema", "database"}, args)
+}
+
+func TestResourceConnectionKafkaRename(t *testing.T) {
+	r := require.New(t)
+	b := newConnectionKafkaBuilder("kafka_conn", "schema", "database")
+	r.Equal(`ALTER CONNECTION "database"."schema"."kafka_conn" RENAME TO "new_conn";`, b.Rename("new_conn"))
+}
+
+func TestResourceConnectionKafkaUpdate(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.MatchExpectationsInOrder(true)
+		mock.ExpectExec(`ALTER CONNECTION "materialize"."public"."kafka_conn" RENAME TO "new_conn";`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery(`SELECT\s+mz_connections.id`).WithArgs("new_conn", "public", "materialize").WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name", "schema", "database", "brokers", "progress_topic"}).AddRow("u1", "new_conn", "public", "materialize", "{localhost:9092}", nil),
+		)
+		mock.ExpectQuery(`SHOW CREATE CONNECTION "materialize"."public"."new_conn"`).WillReturnRows(
+			sqlmock.NewRows([]string{"name", "create_sql"}).AddRow("materialize.public.new_conn", `CREATE CONNECTION "materialize"."public"."new_conn" TO KAFKA (BROKERS = ('localhost:9092'))`),
+		)
+
+		d := UpdateData(t, ConnectionKafka(), "u1", map[string]string{
+			"id":              "u1",
+			"name":            "kafka_conn",
+			"schema_name":     "public",
+			"database_name":   "materialize",
+			"kafka_brokers.#": "1",
+			"kafka_brokers.0": "localhost:9092",
+		}, map[string]interface{}{
+			"name":          "new_conn",
+			"kafka_brokers": []interface{}{"localhost:9092"},
+		}, testMeta(db))
+
+		diags := resourceConnectionKafkaUpdate(context.TODO(), d, testMeta(db))
+		r.False(diags.HasError(), "%v", diags)
+		r.Equal("new_conn", d.Get("name"))
+	})
+}
@@ -0,0 +1,401 @@
+package resources
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ConnectionPostgres() *schema.Resource {
+	return importDefaults(&schema.Resource{
+		Description: "A Postgres connection establishes a link to a single database of a PostgreSQL server.",
+
+		CreateContext: resourceConnectionPostgresCreate,
+		ReadContext:   resourceConnectionPostgresRead,
+		UpdateContext: resourceConnectionPostgresUpdate,
+		DeleteContext: resourceConnectionDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importQualifiedName(resourceConnectionPostgresRead, "database_name", "schema_name", "name"),
+		},
+
+		Timeouts: defaultTimeouts(),
+
+		CustomizeDiff: defaultDatabaseName,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "The identifier for the connection.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"schema_name": {
+				Description: "The identifier for the connection schema.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "public",
+			},
+			"database_name": {
+				Description: "The identifier for the connection database. Defaults to the database of the provider configuration.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"host": {
+				Description: "The hostname of the database.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"port": {
+				Description: "The port of the database.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     5432,
+			},
+			"database": {
+				Description: "The target database.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"user": {
+				Description: "The database username.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"password": {
+				Description:      "The name of the secret containing the password for the database user.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressQualifiedNameDiff,
+				ForceNew:         true,
+			},
+			"ssl_mode": {
+				Description:      "How to negotiate encryption with the server.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressCaseDiff,
+				ForceNew:         true,
+				ValidateFunc:     validation.StringInSlice(sslModes, true),
+			},
+			"ssl_certificate": {
+				Description:      "The name of the secret containing the client's TLS certificate.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressQualifiedNameDiff,
+				ForceNew:         true,
+				RequiredWith:     []string{"ssl_certificate", "ssl_key"},
+			},
+			"ssl_key": {
+				Description:      "The name of the secret containing the client's TLS private key.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressQualifiedNameDiff,
+				ForceNew:         true,
+				RequiredWith:     []string{"ssl_certificate", "ssl_key"},
+			},
+			"ssl_root_cert": {
+				Description:      "The name of the secret containing the certificate authority used to validate the server's TLS certificate.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressQualifiedNameDiff,
+				ForceNew:         true,
+			},
+			"ssh_tunnel": {
+				Description:      "The name of an SSH tunnel connection to route network traffic through.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressQualifiedNameDiff,
+				ForceNew:         true,
+				ConflictsWith:    []string{"aws_privatelink"},
+			},
+			"aws_privatelink": {
+				Description:      "The name of an AWS PrivateLink connection to route network traffic through.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressQualifiedNameDiff,
+				ForceNew:         true,
+				ConflictsWith:    []string{"ssh_tunnel"},
+			},
+		},
+	})
+}
+
+type ConnectionPostgresBuilder struct {
+	ConnectionBuilder
+	host           string
+	port           int
+	database       string
+	user           string
+	password       string
+	sslMode        string
+	sslCertificate string
+	sslKey         string
+	sslRootCert    string
+	sshTunnel      string
+	awsPrivateLink string
+}
+
+func newConnectionPostgresBuilder(connectionName, schemaName, databaseName string) *ConnectionPostgresBuilder {
+	return &ConnectionPostgresBuilder{
+		ConnectionBuilder: ConnectionBuilder{
+			connectionName: connectionName,
+			schemaName:     schemaName,
+			databaseName:   databaseName,
+		},
+	}
+}
+
+func (b *ConnectionPostgresBuilder) Host(h string) *ConnectionPostgresBuilder {
+	b.host = h
+	return b
+}
+
+func (b *ConnectionPostgresBuilder) Port(p int) *ConnectionPostgresBuilder {
+	b.port = p
+	return b
+}
+
+func (b *ConnectionPostgresBuilder) Database(d string) *ConnectionPostgresBuilder {
+	b.database = d
+	return b
+}
+
+func (b *ConnectionPostgresBuilder) User(u string) *ConnectionPostgresBuilder {
+	b.user = u
+	return b
+}
+
+func (b *ConnectionPostgresBuilder) Password(p string) *ConnectionPostgresBuilder {
+	b.password = p
+	return b
+}
+
+func (b *ConnectionPostgresBuilder) SslMode(s string) *ConnectionPostgresBuilder {
+	b.sslMode = s
+	return b
+}
+
+func (b *ConnectionPostgresBuilder) SslCertificate(s string) *ConnectionPostgresBuilder {
+	b.sslCertificate = s
+	return b
+}
+
+func (b *ConnectionPostgresBuilder) SslKey(s string) *ConnectionPostgresBuilder {
+	b.sslKey = s
+	return b
+}
+
+func (b *ConnectionPostgresBuilder) SslRootCert(s string) *ConnectionPostgresBuilder {
+	b.sslRootCert = s
+	return b
+}
+
+func (b *ConnectionPostgresBuilder) SshTunnel(s string) *ConnectionPostgresBuilder {
+	b.sshTunnel = s
+	return b
+}
+
+func (b *ConnectionPostgresBuilder) AwsPrivateLink(a string) *ConnectionPostgresBuilder {
+	b.awsPrivateLink = a
+	return b
+}
+
+func (b *ConnectionPostgresBuilder) Create() string {
+	q := strings.Builder{}
+	q.WriteString(fmt.Sprintf(`CREATE CONNECTION %s TO POSTGRES`, qualifiedName(b.databaseName, b.schemaName, b.connectionName)))
+
+	var p []string
+	p = append(p, fmt.Sprintf(`HOST %s`, quoteString(b.host)))
+
+	if b.port != 0 {
+		p = append(p, fmt.Sprintf(`PORT %d`, b.port))
+	}
+
+	p = append(p, fmt.Sprintf(`USER %s`, quoteString(b.user)))
+
+	if b.password != "" {
+		p = append(p, fmt.Sprintf(`PASSWORD SECRET %s`, quoteReference(b.password)))
+	}
+
+	if b.sslMode != "" {
+		p = append(p, fmt.Sprintf(`SSL MODE %s`, quoteString(strings.ToLower(b.sslMode))))
+	}
+
+	if b.sslCertificate != "" {
+		p = append(p, fmt.Sprintf(`SSL CERTIFICATE SECRET %s`, quoteReference(b.sslCertificate)))
+	}
+
+	if b.sslKey != "" {
+		p = append(p, fmt.Sprintf(`SSL KEY SECRET %s`, quoteReference(b.sslKey)))
+	}
+
+	if b.sslRootCert != "" {
+		p = append(p, fmt.Sprintf(`SSL CERTIFICATE AUTHORITY SECRET %s`, quoteReference(b.sslRootCert)))
+	}
+
+	if b.sshTunnel != "" {
+		p = append(p, fmt.Sprintf(`SSH TUNNEL %s`, quoteReference(b.sshTunnel)))
+	}
+
+	if b.awsPrivateLink != "" {
+		p = append(p, fmt.Sprintf(`AWS PRIVATELINK %s`, quoteReference(b.awsPrivateLink)))
+	}
+
+	p = append(p, fmt.Sprintf(`DATABASE %s`, quoteString(b.database)))
+
+	q.WriteString(fmt.Sprintf(` (%s);`, strings.Join(p[:], ", ")))
+	return q.String()
+}
+
+func (b *ConnectionPostgresBuilder) Read() (string, []interface{}) {
+	return `
+		SELECT
+			mz_connections.id,
+			mz_connections.name,
+			mz_schemas.name,
+			mz_databases.name
+		FROM mz_connections
+		JOIN mz_schemas
+			ON mz_connections.schema_id = mz_schemas.id
+		JOIN mz_databases
+			ON mz_schemas.database_id = mz_databases.id
+		WHERE mz_connections.name = $1
+		AND mz_schemas.name = $2
+		AND mz_databases.name = $3
+		AND mz_connections.type = 'postgres';
+	`, []interface{}{b.connectionName, b.schemaName, b.databaseName}
+}
+
+func resourceConnectionPostgresRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	conn := meta.(*ProviderMeta)
+	connectionName := d.Get("name").(string)
+	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
+
+	builder := newConnectionPostgresBuilder(connectionName, schemaName, databaseName)
+	q, args := builder.Read()
+
+	var id, name, schema, database string
+	if err := queryRow(ctx, conn, q, args, &id, &name, &schema, &database); err == sql.ErrNoRows {
+		log.Printf("[WARN] connection (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return diags
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id)
+	d.Set("name", name)
+	d.Set("schema_name", schema)
+	d.Set("database_name", database)
+
+	options, err := readConnectionOptions(ctx, conn, &builder.ConnectionBuilder)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	port := 5432
+	if v, ok := options["PORT"]; ok {
+		if port, err = strconv.Atoi(v); err != nil {
+			return diag.FromErr(fmt.Errorf("unexpected port %q for connection %s: %s", v, name, err))
+		}
+	}
+
+	d.Set("host", options["HOST"])
+	d.Set("port", port)
+	d.Set("database", options["DATABASE"])
+	d.Set("user", options["USER"])
+	d.Set("password", options["PASSWORD"])
+	d.Set("ssl_mode", options["SSL MODE"])
+	d.Set("ssl_certificate", options["SSL CERTIFICATE"])
+	d.Set("ssl_key", options["SSL KEY"])
+	d.Set("ssl_root_cert", options["SSL CERTIFICATE AUTHORITY"])
+	d.Set("ssh_tunnel", options["SSH TUNNEL"])
+	d.Set("aws_privatelink", options["AWS PRIVATELINK"])
+
+	return diags
+}
+
+func resourceConnectionPostgresCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*ProviderMeta)
+
+	connectionName := d.Get("name").(string)
+	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
+
+	builder := newConnectionPostgresBuilder(connectionName, schemaName, databaseName)
+
+	if v, ok := d.GetOk("host"); ok {
+		builder.Host(v.(string))
+	}
+
+	if v, ok := d.GetOk("port"); ok {
+		builder.Port(v.(int))
+	}
+
+	if v, ok := d.GetOk("database"); ok {
+		builder.Database(v.(string))
+	}
+
+	if v, ok := d.GetOk("user"); ok {
+		builder.User(v.(string))
+	}
+
+	if v, ok := d.GetOk("password"); ok {
+		builder.Password(v.(string))
+	}
+
+	if v, ok := d.GetOk("ssl_mode"); ok {
+		builder.SslMode(v.(string))
+	}
+
+	if v, ok := d.GetOk("ssl_certificate"); ok {
+		builder.SslCertificate(v.(string))
+	}
+
+	if v, ok := d.GetOk("ssl_key"); ok {
+		builder.SslKey(v.(string))
+	}
+
+	if v, ok := d.GetOk("ssl_root_cert"); ok {
+		builder.SslRootCert(v.(string))
+	}
+
+	if v, ok := d.GetOk("ssh_tunnel"); ok {
+		builder.SshTunnel(v.(string))
+	}
+
+	if v, ok := d.GetOk("aws_privatelink"); ok {
+		builder.AwsPrivateLink(v.(string))
+	}
+
+	q := builder.Create()
+
+	if diags := ExecResource(ctx, conn, q); diags.HasError() {
+		return diags
+	}
+	return resourceConnectionPostgresRead(ctx, d, meta)
+}
+
+func resourceConnectionPostgresUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := resourceConnectionUpdate(ctx, d, meta); diags.HasError() {
+		return diags
+	}
+
+	return resourceConnectionPostgresRead(ctx, d, meta)
+}
@@ -0,0 +1,115 @@
+package resources
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceConnectionPostgresCreate(t *testing.T) {
+	r := require.New(t)
+	b := newConnectionPostgresBuilder("pg_conn", "schema", "database")
+	b.Host("postgres_host")
+	b.Port(5432)
+	b.User("user")
+	b.Password("schema.password")
+	b.Database("default")
+	r.Equal(`CREATE CONNECTION "database"."schema"."pg_conn" TO POSTGRES (HOST 'postgres_host', PORT 5432, USER 'user', PASSWORD SECRET "schema"."password", DATABASE 'default');`, b.Create())
+}
+
+func TestResourceConnectionPostgresCreateSsl(t *testing.T) {
+	r := require.New(t)
+	b := newConnectionPostgresBuilder("pg_conn", "schema", "database")
+	b.Host("postgres_host")
+	b.Port(5432)
+	b.User("user")
+	b.Password("schema.password")
+	b.SslMode("verify-full")
+	b.SslCertificate("schema.cert")
+	b.SslKey("schema.key")
+	b.SslRootCert("schema.ca")
+	b.Database("default")
+	r.Equal(`CREATE CONNECTION "database"."schema"."pg_conn" TO POSTGRES (HOST 'postgres_host', PORT 5432, USER 'user', PASSWORD SECRET "schema"."password", SSL MODE 'verify-full', SSL CERTIFICATE SECRET "schema"."cert", SSL KEY SECRET "schema"."key", SSL CERTIFICATE AUTHORITY SECRET "schema"."ca", DATABASE 'default');`, b.Create())
+}
+
+func TestResourceConnectionPostgresCreateSshTunnel(t *testing.T) {
+	r := require.New(t)
+	b := newConnectionPostgresBuilder("pg_conn", "schema", "database")
+	b.Host("postgres_host")
+	b.Port(5432)
+	b.User("user")
+	b.Password("schema.password")
+	b.SshTunnel("schema.ssh_conn")
+	b.Database("default")
+	r.Equal(`CREATE CONNECTION "database"."schema"."pg_conn" TO POSTGRES (HOST 'postgres_host', PORT 5432, USER 'user', PASSWORD SECRET "schema"."password", SSH TUNNEL "schema"."ssh_conn", DATABASE 'default');`, b.Create())
+}
+
+func TestResourceConnectionPostgresCreateAwsPrivateLink(t *testing.T) {
+	r := require.New(t)
+	b := newConnectionPostgresBuilder("pg_conn", "schema", "database")
+	b.Host("postgres_host")
+	b.Port(5432)
+	b.User("user")
+	b.Password("schema.password")
+	b.AwsPrivateLink("schema.privatelink_conn")
+	b.Database("default")
+	r.Equal(`CREATE CONNECTION "database"."schema"."pg_conn" TO POSTGRES (HOST 'postgres_host', PORT 5432, USER 'user', PASSWORD SECRET "schema"."password", AWS PRIVATELINK "schema"."privatelink_conn", DATABASE 'default');`, b.Create())
+}
+
+func TestResourceConnectionPostgresRead(t *testing.T) {
+	r := require.New(t)
+	b := newConnectionPostgresBuilder("pg_conn", "schema", "database")
+	q, args := b.Read()
+	r.Equal(`
+		SELECT
+			mz_connections.id,
+			mz_connections.name,
+			mz_schemas.name,
+			mz_databases.name
+		FROM mz_connections
+		JOIN mz_schemas
+			ON mz_connections.schema_id = mz_schemas.id
+		JOIN mz_databases
+			ON mz_schemas.database_id = mz_databases.id
+		WHERE mz_connections.name = $1
+		AND mz_schemas.name = $2
+		AND mz_databases.name = $3
+		AND mz_connections.type = 'postgres';
+	`, q)
+	r.Equal([]interface{}{"pg_conn", "schema", "database"}, args)
+}
+
+func TestResourceConnectionPostgresRename(t *testing.T) {
+	r := require.New(t)
+	b := newConnectionPostgresBuilder("pg_conn", "schema", "database")
+	r.Equal(`ALTER CONNECTION "database"."schema"."pg_conn" RENAME TO "new_conn";`, b.Rename("new_conn"))
+}
+
+func TestResourceConnectionPostgresReadOptions(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(`SELECT\s+mz_connections.id`).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name", "schema", "database"}).AddRow("u1", "pg_conn", "public", "materialize"),
+		)
+		mock.ExpectQuery(`SHOW CREATE CONNECTION "materialize"."public"."pg_conn"`).WillReturnRows(
+			sqlmock.NewRows([]string{"name", "create_sql"}).AddRow("materialize.public.pg_conn", `CREATE CONNECTION "materialize"."public"."pg_conn" TO POSTGRES (HOST = 'postgres_host', PORT = 5433, USER = 'user', PASSWORD = SECRET "materialize"."public"."password", SSL MODE = 'require', DATABASE = 'default')`),
+		)
+
+		d := schema.TestResourceDataRaw(t, ConnectionPostgres().Schema, map[string]interface{}{"name": "pg_conn", "database_name": "materialize"})
+		diags := resourceConnectionPostgresRead(context.TODO(), d, testMeta(db))
+		r.False(diags.HasError())
+		r.Equal("u1", d.Id())
+		r.Equal("postgres_host", d.Get("host"))
+		r.Equal(5433, d.Get("port"))
+		r.Equal("user", d.Get("user"))
+		r.Equal("default", d.Get("database"))
+		r.Equal("materialize.public.password", d.Get("password"))
+		r.Equal("require", d.Get("ssl_mode"))
+		r.Equal("", d.Get("ssh_tunnel"))
+	})
+}
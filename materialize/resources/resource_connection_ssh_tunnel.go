@@ -0,0 +1,254 @@
+package resources
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ConnectionSshTunnel() *schema.Resource {
+	return importDefaults(&schema.Resource{
+		Description: "An SSH tunnel connection establishes a link to an SSH bastion server.",
+
+		CreateContext: resourceConnectionSshTunnelCreate,
+		ReadContext:   resourceConnectionSshTunnelRead,
+		UpdateContext: resourceConnectionSshTunnelUpdate,
+		DeleteContext: resourceConnectionDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importQualifiedName(resourceConnectionSshTunnelRead, "database_name", "schema_name", "name"),
+		},
+
+		Timeouts: defaultTimeouts(),
+
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			// Rotating the keys replaces both public keys
+			if d.HasChange("rotation_trigger") && d.Id() != "" {
+				d.SetNewComputed("public_key_1")
+				d.SetNewComputed("public_key_2")
+			}
+			return defaultDatabaseName(ctx, d, meta)
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "The identifier for the connection.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"schema_name": {
+				Description: "The identifier for the connection schema.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "public",
+			},
+			"database_name": {
+				Description: "The identifier for the connection database. Defaults to the database of the provider configuration.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"host": {
+				Description: "The hostname of the SSH bastion server.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"port": {
+				Description: "The port of the SSH bastion server.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     22,
+			},
+			"user": {
+				Description: "The name of the user to connect to the SSH bastion server as.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"rotation_trigger": {
+				Description: "An arbitrary value that, when changed, rotates the key pairs of the connection.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"public_key_1": {
+				Description: "The first public key associated with the SSH tunnel.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"public_key_2": {
+				Description: "The second public key associated with the SSH tunnel.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	})
+}
+
+type ConnectionSshTunnelBuilder struct {
+	ConnectionBuilder
+	host string
+	port int
+	user string
+}
+
+func newConnectionSshTunnelBuilder(connectionName, schemaName, databaseName string) *ConnectionSshTunnelBuilder {
+	return &ConnectionSshTunnelBuilder{
+		ConnectionBuilder: ConnectionBuilder{
+			connectionName: connectionName,
+			schemaName:     schemaName,
+			databaseName:   databaseName,
+		},
+	}
+}
+
+func (b *ConnectionSshTunnelBuilder) Host(h string) *ConnectionSshTunnelBuilder {
+	b.host = h
+	return b
+}
+
+func (b *ConnectionSshTunnelBuilder) Port(p int) *ConnectionSshTunnelBuilder {
+	b.port = p
+	return b
+}
+
+func (b *ConnectionSshTunnelBuilder) User(u string) *ConnectionSshTunnelBuilder {
+	b.user = u
+	return b
+}
+
+func (b *ConnectionSshTunnelBuilder) Create() string {
+	return fmt.Sprintf(`CREATE CONNECTION %s TO SSH TUNNEL (HOST %s, USER %s, PORT %d);`, qualifiedName(b.databaseName, b.schemaName, b.connectionName), quoteString(b.host), quoteString(b.user), b.port)
+}
+
+func (b *ConnectionSshTunnelBuilder) Read() (string, []interface{}) {
+	return `
+		SELECT
+			mz_connections.id,
+			mz_connections.name,
+			mz_schemas.name,
+			mz_databases.name,
+			mz_ssh_tunnel_connections.public_key_1,
+			mz_ssh_tunnel_connections.public_key_2
+		FROM mz_connections
+		JOIN mz_schemas
+			ON mz_connections.schema_id = mz_schemas.id
+		JOIN mz_databases
+			ON mz_schemas.database_id = mz_databases.id
+		JOIN mz_ssh_tunnel_connections
+			ON mz_connections.id = mz_ssh_tunnel_connections.id
+		WHERE mz_connections.name = $1
+		AND mz_schemas.name = $2
+		AND mz_databases.name = $3;
+	`, []interface{}{b.connectionName, b.schemaName, b.databaseName}
+}
+
+func (b *ConnectionSshTunnelBuilder) RotateKeys() string {
+	return fmt.Sprintf(`ALTER CONNECTION %s ROTATE KEYS;`, qualifiedName(b.databaseName, b.schemaName, b.connectionName))
+}
+
+func resourceConnectionSshTunnelRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	conn := meta.(*ProviderMeta)
+	connectionName := d.Get("name").(string)
+	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
+
+	builder := newConnectionSshTunnelBuilder(connectionName, schemaName, databaseName)
+	q, args := builder.Read()
+
+	var id, name, schema, database, publicKey1, publicKey2 string
+	if err := queryRow(ctx, conn, q, args, &id, &name, &schema, &database, &publicKey1, &publicKey2); err == sql.ErrNoRows {
+		log.Printf("[WARN] connection (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return diags
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id)
+	d.Set("name", name)
+	d.Set("schema_name", schema)
+	d.Set("database_name", database)
+	d.Set("public_key_1", publicKey1)
+	d.Set("public_key_2", publicKey2)
+
+	options, err := readConnectionOptions(ctx, conn, &builder.ConnectionBuilder)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	port := 22
+	if v, ok := options["PORT"]; ok {
+		if port, err = strconv.Atoi(v); err != nil {
+			return diag.FromErr(fmt.Errorf("unexpected port %q for connection %s: %s", v, name, err))
+		}
+	}
+
+	d.Set("host", options["HOST"])
+	d.Set("port", port)
+	d.Set("user", options["USER"])
+
+	return diags
+}
+
+func resourceConnectionSshTunnelCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*ProviderMeta)
+
+	connectionName := d.Get("name").(string)
+	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
+
+	builder := newConnectionSshTunnelBuilder(connectionName, schemaName, databaseName)
+
+	if v, ok := d.GetOk("host"); ok {
+		builder.Host(v.(string))
+	}
+
+	if v, ok := d.GetOk("port"); ok {
+		builder.Port(v.(int))
+	}
+
+	if v, ok := d.GetOk("user"); ok {
+		builder.User(v.(string))
+	}
+
+	q := builder.Create()
+
+	if diags := ExecResource(ctx, conn, q); diags.HasError() {
+		return diags
+	}
+	return resourceConnectionSshTunnelRead(ctx, d, meta)
+}
+
+func resourceConnectionSshTunnelUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*ProviderMeta)
+
+	if diags := resourceConnectionUpdate(ctx, d, meta); diags.HasError() {
+		return diags
+	}
+
+	if d.HasChange("rotation_trigger") {
+		connectionName := d.Get("name").(string)
+		schemaName := d.Get("schema_name").(string)
+		databaseName := d.Get("database_name").(string)
+
+		builder := newConnectionSshTunnelBuilder(connectionName, schemaName, databaseName)
+		q := builder.RotateKeys()
+
+		if diags := ExecResource(ctx, conn, q); diags.HasError() {
+			return diags
+		}
+	}
+
+	return resourceConnectionSshTunnelRead(ctx, d, meta)
+}
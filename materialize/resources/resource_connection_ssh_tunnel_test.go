@@ -0,0 +1,95 @@
+package resources
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceConnectionSshTunnelCreate(t *testing.T) {
+	r := require.New(t)
+	b := newConnectionSshTunnelBuilder("ssh_conn", "schema", "database")
+	b.Host("localhost")
+	b.Port(123)
+	b.User("user")
+	r.Equal(`CREATE CONNECTION "database"."schema"."ssh_conn" TO SSH TUNNEL (HOST 'localhost', USER 'user', PORT 123);`, b.Create())
+}
+
+func TestResourceConnectionSshTunnelRead(t *testing.T) {
+	r := require.New(t)
+	b := newConnectionSshTunnelBuilder("ssh_conn", "schema", "database")
+	q, args := b.Read()
+	r.Equal(`
+		SELECT
+			mz_connections.id,
+			mz_connections.name,
+			mz_schemas.name,
+			mz_databases.name,
+			mz_ssh_tunnel_connections.public_key_1,
+			mz_ssh_tunnel_connections.public_key_2
+		FROM mz_connections
+		JOIN mz_schemas
+			ON mz_connections.schema_id = mz_schemas.id
+		JOIN mz_databases
+			ON mz_schemas.database_id = mz_databases.id
+		JOIN mz_ssh_tunnel_connections
+			ON mz_connections.id = mz_ssh_tunnel_connections.id
+		WHERE mz_connections.name = $1
+		AND mz_schemas.name = $2
+		AND mz_databases.name = $3;
+	`, q)
+	r.Equal([]interface{}{"ssh_conn", "schema", "database"}, args)
+}
+
+func TestResourceConnectionSshTunnelRename(t *testing.T) {
+	r := require.New(t)
+	b := newConnectionSshTunnelBuilder("ssh_conn", "schema", "database")
+	r.Equal(`ALTER CONNECTION "database"."schema"."ssh_conn" RENAME TO "new_conn";`, b.Rename("new_conn"))
+}
+
+func TestResourceConnectionSshTunnelRotateKeys(t *testing.T) {
+	r := require.New(t)
+	b := newConnectionSshTunnelBuilder("ssh_conn", "schema", "database")
+	r.Equal(`ALTER CONNECTION "database"."schema"."ssh_conn" ROTATE KEYS;`, b.RotateKeys())
+}
+
+func TestResourceConnectionSshTunnelUpdateRenameError(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.ExpectExec(`ALTER CONNECTION .* RENAME TO "ssh_conn";`).WillReturnError(&pq.Error{Code: "42710", Message: "catalog item 'ssh_conn' already exists"})
+
+		d := schema.TestResourceDataRaw(t, ConnectionSshTunnel().Schema, map[string]interface{}{"name": "ssh_conn"})
+		d.SetId("u1")
+
+		diags := resourceConnectionSshTunnelUpdate(context.TODO(), d, testMeta(db))
+		r.True(diags.HasError())
+		r.Contains(diags[0].Summary, "already exists")
+	})
+}
+
+func TestResourceConnectionSshTunnelImport(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(`SELECT\s+mz_connections.id`).WithArgs("ssh_conn", "public", "materialize").WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name", "schema", "database", "public_key_1", "public_key_2"}).AddRow("u1", "ssh_conn", "public", "materialize", "ssh-ed25519 AAAA1", "ssh-ed25519 AAAA2"),
+		)
+		mock.ExpectQuery(`SHOW CREATE CONNECTION "materialize"."public"."ssh_conn"`).WillReturnRows(
+			sqlmock.NewRows([]string{"name", "create_sql"}).AddRow("materialize.public.ssh_conn", `CREATE CONNECTION "materialize"."public"."ssh_conn" TO SSH TUNNEL (HOST = 'bastion', PORT = 2222, USER = 'ubuntu')`),
+		)
+
+		diff := ImportPlan(t, ConnectionSshTunnel(), "materialize.public.ssh_conn", map[string]interface{}{
+			"name": "ssh_conn",
+			"host": "bastion",
+			"port": 2222,
+			"user": "ubuntu",
+		}, testMeta(db))
+		r.True(diff.Empty(), "unexpected diff: %v", diff)
+	})
+}
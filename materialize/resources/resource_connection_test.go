@@ -0,0 +1,50 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceConnectionRename(t *testing.T) {
+	r := require.New(t)
+	b := newConnectionBuilder("connection", "schema", "database")
+	r.Equal(`ALTER CONNECTION "database"."schema"."connection" RENAME TO "new_connection";`, b.Rename("new_connection"))
+}
+
+func TestResourceConnectionDrop(t *testing.T) {
+	r := require.New(t)
+	b := newConnectionBuilder("connection", "schema", "database")
+	r.Equal(`DROP CONNECTION "database"."schema"."connection";`, b.Drop())
+}
+
+func TestResourceConnectionShowCreate(t *testing.T) {
+	r := require.New(t)
+	b := newConnectionBuilder("connection", "schema", "database")
+	r.Equal(`SHOW CREATE CONNECTION "database"."schema"."connection";`, b.ShowCreate())
+}
+
+func TestParseConnectionOptions(t *testing.T) {
+	r := require.New(t)
+	o := parseConnectionOptions(`CREATE CONNECTION "materialize"."public"."pg (conn), x" TO POSTGRES (HOST = 'postgres.example.com', PORT = 5432, USER = 'it''s', PASSWORD = SECRET "materialize"."my schema"."pass, word", SSL MODE = 'require', SSH TUNNEL = "materialize"."public"."ssh", DATABASE = 'db')`)
+	r.Equal(map[string]string{
+		"HOST":       "postgres.example.com",
+		"PORT":       "5432",
+		"USER":       "it's",
+		"PASSWORD":   "materialize.my schema.pass, word",
+		"SSL MODE":   "require",
+		"SSH TUNNEL": "materialize.public.ssh",
+		"DATABASE":   "db",
+	}, o)
+}
+
+func TestParseConnectionOptionsWithoutEquals(t *testing.T) {
+	r := require.New(t)
+	o := parseConnectionOptions(`CREATE CONNECTION "database"."schema"."conn" TO POSTGRES (HOST 'host', PORT 5432, PASSWORD SECRET "schema"."password", SSL CERTIFICATE AUTHORITY SECRET "schema"."ca")`)
+	r.Equal(map[string]string{
+		"HOST":                      "host",
+		"PORT":                      "5432",
+		"PASSWORD":                  "schema.password",
+		"SSL CERTIFICATE AUTHORITY": "schema.ca",
+	}, o)
+}
@@ -4,19 +4,27 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func Database() *schema.Resource {
-	return &schema.Resource{
+	return importDefaults(&schema.Resource{
 		Description: "The highest level namespace hierarchy in Materialize.",
 
 		CreateContext: resourceDatabaseCreate,
 		ReadContext:   resourceDatabaseRead,
+		UpdateContext: resourceDatabaseUpdate,
 		DeleteContext: resourceDatabaseDelete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: importQualifiedName(resourceDatabaseRead, "name"),
+		},
+
+		Timeouts: defaultTimeouts(),
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Description: "The identifier for the database.",
@@ -24,8 +32,19 @@ func Database() *schema.Resource {
 				Optional:    true,
 				ForceNew:    true,
 			},
+			"ownership_role": {
+				Description: "The owner of the object.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"comment": {
+				Description: "A comment describing the object.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
 		},
-	}
+	})
 }
 
 type DatabaseBuilder struct {
@@ -39,54 +58,125 @@ func newDatabaseBuilder(databaseName string) *DatabaseBuilder {
 }
 
 func (b *DatabaseBuilder) Create() string {
-	return fmt.Sprintf(`CREATE DATABASE %s;`, b.databaseName)
+	return fmt.Sprintf(`CREATE DATABASE %s;`, quoteIdentifier(b.databaseName))
 }
 
-func (b *DatabaseBuilder) Read() string {
-	return fmt.Sprintf(`SELECT id, name FROM mz_databases WHERE name = '%s';`, b.databaseName)
+func (b *DatabaseBuilder) Read() (string, []interface{}) {
+	return `
+		SELECT mz_databases.id, mz_databases.name, mz_roles.name, mz_comments.comment
+		FROM mz_databases JOIN mz_roles
+			ON mz_databases.owner_id = mz_roles.id
+		LEFT JOIN mz_internal.mz_comments
+			ON mz_databases.id = mz_comments.id
+			AND mz_comments.object_type = 'database'
+			AND mz_comments.object_sub_id IS NULL
+		WHERE mz_databases.name = $1;
+	`, []interface{}{b.databaseName}
+}
+
+func (b *DatabaseBuilder) AlterOwner(roleName string) string {
+	return fmt.Sprintf(`ALTER DATABASE %s OWNER TO %s;`, quoteIdentifier(b.databaseName), quoteIdentifier(roleName))
+}
+
+func (b *DatabaseBuilder) Comment(comment string) string {
+	if comment == "" {
+		return fmt.Sprintf(`COMMENT ON DATABASE %s IS NULL;`, quoteIdentifier(b.databaseName))
+	}
+	return fmt.Sprintf(`COMMENT ON DATABASE %s IS %s;`, quoteIdentifier(b.databaseName), quoteString(comment))
 }
 
 func (b *DatabaseBuilder) Drop() string {
-	return fmt.Sprintf(`DROP DATABASE %s;`, b.databaseName)
+	return fmt.Sprintf(`DROP DATABASE %s;`, quoteIdentifier(b.databaseName))
 }
 
 func resourceDatabaseRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	conn := meta.(*sql.DB)
+	conn := meta.(*ProviderMeta)
 	databaseName := d.Get("name").(string)
 
 	builder := newDatabaseBuilder(databaseName)
-	q := builder.Read()
-
-	var id, name string
-	conn.QueryRow(q).Scan(&id, &name)
+	q, args := builder.Read()
+
+	var id, name, owner string
+	var comment sql.NullString
+	if err := queryRow(ctx, conn, q, args, &id, &name, &owner, &comment); err == sql.ErrNoRows {
+		log.Printf("[WARN] database (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return diags
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
 
 	d.SetId(id)
+	d.Set("name", name)
+	d.Set("ownership_role", owner)
+	d.Set("comment", comment.String)
 
 	return diags
 }
 
 func resourceDatabaseCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	conn := meta.(*sql.DB)
+	conn := meta.(*ProviderMeta)
 	databaseName := d.Get("name").(string)
 
 	builder := newDatabaseBuilder(databaseName)
 	q := builder.Create()
 
-	ExecResource(conn, q)
+	if diags := ExecResource(ctx, conn, q); diags.HasError() {
+		return diags
+	}
+
+	if v, ok := d.GetOk("ownership_role"); ok {
+		if diags := ExecResource(ctx, conn, builder.AlterOwner(v.(string))); diags.HasError() {
+			return diags
+		}
+	}
+
+	if v, ok := d.GetOk("comment"); ok {
+		if diags := ExecResource(ctx, conn, builder.Comment(v.(string))); diags.HasError() {
+			return diags
+		}
+	}
+
 	return resourceDatabaseRead(ctx, d, meta)
 }
 
-func resourceDatabaseDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
+func resourceDatabaseUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*ProviderMeta)
+	databaseName := d.Get("name").(string)
+
+	if d.HasChange("ownership_role") {
+		_, newRole := d.GetChange("ownership_role")
+
+		builder := newDatabaseBuilder(databaseName)
+		q := builder.AlterOwner(newRole.(string))
+
+		if diags := ExecResource(ctx, conn, q); diags.HasError() {
+			return diags
+		}
+	}
 
-	conn := meta.(*sql.DB)
+	if d.HasChange("comment") {
+		_, newComment := d.GetChange("comment")
+
+		builder := newDatabaseBuilder(databaseName)
+		q := builder.Comment(newComment.(string))
+
+		if diags := ExecResource(ctx, conn, q); diags.HasError() {
+			return diags
+		}
+	}
+
+	return resourceDatabaseRead(ctx, d, meta)
+}
+
+func resourceDatabaseDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*ProviderMeta)
 	databaseName := d.Get("name").(string)
 
 	builder := newDatabaseBuilder(databaseName)
 	q := builder.Drop()
 
-	ExecResource(conn, q)
-	return diags
+	return ExecResource(ctx, conn, q)
 }
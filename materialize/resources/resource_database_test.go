@@ -1,25 +1,84 @@
 package resources
 
 import (
+	"context"
+	"database/sql"
 	"testing"
 
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/require"
 )
 
 func TestResourceDatabaseCreate(t *testing.T) {
 	r := require.New(t)
 	b := newDatabaseBuilder("database")
-	r.Equal(`CREATE DATABASE database;`, b.Create())
+	r.Equal(`CREATE DATABASE "database";`, b.Create())
 }
 
 func TestResourceDatabaseRead(t *testing.T) {
 	r := require.New(t)
 	b := newDatabaseBuilder("database")
-	r.Equal(`SELECT id, name FROM mz_databases WHERE name = 'database';`, b.Read())
+	q, args := b.Read()
+	r.Equal(`
+		SELECT mz_databases.id, mz_databases.name, mz_roles.name, mz_comments.comment
+		FROM mz_databases JOIN mz_roles
+			ON mz_databases.owner_id = mz_roles.id
+		LEFT JOIN mz_internal.mz_comments
+			ON mz_databases.id = mz_comments.id
+			AND mz_comments.object_type = 'database'
+			AND mz_comments.object_sub_id IS NULL
+		WHERE mz_databases.name = $1;
+	`, q)
+	r.Equal([]interface{}{"database"}, args)
+}
+
+func TestResourceDatabaseAlterOwner(t *testing.T) {
+	r := require.New(t)
+	b := newDatabaseBuilder("database")
+	r.Equal(`ALTER DATABASE "database" OWNER TO "role";`, b.AlterOwner("role"))
+}
+
+func TestResourceDatabaseComment(t *testing.T) {
+	r := require.New(t)
+	b := newDatabaseBuilder("database")
+	r.Equal(`COMMENT ON DATABASE "database" IS 'A comment';`, b.Comment("A comment"))
+}
+
+func TestResourceDatabaseCommentRemove(t *testing.T) {
+	r := require.New(t)
+	b := newDatabaseBuilder("database")
+	r.Equal(`COMMENT ON DATABASE "database" IS NULL;`, b.Comment(""))
 }
 
 func TestResourceDatabaseDrop(t *testing.T) {
 	r := require.New(t)
 	b := newDatabaseBuilder("database")
-	r.Equal(`DROP DATABASE database;`, b.Drop())
+	r.Equal(`DROP DATABASE "database";`, b.Drop())
+}
+
+func TestResourceDatabaseReadMissing(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		rows := sqlmock.NewRows([]string{"id", "name", "owner", "comment"})
+		mock.ExpectQuery(`SELECT mz_databases.id`).WillReturnRows(rows)
+
+		d := schema.TestResourceDataRaw(t, Database().Schema, map[string]interface{}{"name": "database"})
+		d.SetId("u1")
+
+		diags := resourceDatabaseRead(context.TODO(), d, testMeta(db))
+		r.False(diags.HasError())
+		r.Equal("", d.Id())
+	})
+}
+
+func TestResourceDatabaseUnusualName(t *testing.T) {
+	r := require.New(t)
+	b := newDatabaseBuilder(`My"db.x-y`)
+	r.Equal(`CREATE DATABASE "My""db.x-y";`, b.Create())
+	r.Equal(`COMMENT ON DATABASE "My""db.x-y" IS 'It''s a database';`, b.Comment("It's a database"))
+
+	_, args := b.Read()
+	r.Equal([]interface{}{`My"db.x-y`}, args)
 }
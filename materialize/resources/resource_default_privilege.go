@@ -0,0 +1,264 @@
+package resources
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func DefaultPrivilege() *schema.Resource {
+	return importDefaults(&schema.Resource{
+		Description: "Manages the privileges granted on objects created in the future by a role.",
+
+		CreateContext: resourceDefaultPrivilegeCreate,
+		ReadContext:   resourceDefaultPrivilegeRead,
+		DeleteContext: resourceDefaultPrivilegeDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDefaultPrivilegeImport,
+		},
+
+		Timeouts: defaultTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			"target_role_name": {
+				Description: "The role whose newly created objects receive the privilege.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"grantee_name": {
+				Description: "The role that is granted the privilege.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"object_type": {
+				Description:  "The type of object the privilege applies to.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(defaultPrivilegeObjectTypes, true),
+				StateFunc: func(v interface{}) string {
+					return strings.ToUpper(v.(string))
+				},
+			},
+			"privilege": {
+				Description:  "The privilege to grant.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"SELECT", "INSERT", "UPDATE", "DELETE", "USAGE", "CREATE"}, true),
+				StateFunc: func(v interface{}) string {
+					return strings.ToUpper(v.(string))
+				},
+			},
+			"database_name": {
+				Description: "Only apply to objects created in this database.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"schema_name": {
+				Description:  "Only apply to objects created in this schema.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"database_name"},
+			},
+		},
+	})
+}
+
+type DefaultPrivilegeBuilder struct {
+	targetRoleName string
+	granteeName    string
+	objectType     string
+	privilege      string
+	databaseName   string
+	schemaName     string
+}
+
+func newDefaultPrivilegeBuilder(targetRoleName, granteeName, objectType, privilege string) *DefaultPrivilegeBuilder {
+	return &DefaultPrivilegeBuilder{
+		targetRoleName: targetRoleName,
+		granteeName:    granteeName,
+		objectType:     strings.ToUpper(objectType),
+		privilege:      strings.ToUpper(privilege),
+	}
+}
+
+func (b *DefaultPrivilegeBuilder) DatabaseName(d string) *DefaultPrivilegeBuilder {
+	b.databaseName = d
+	return b
+}
+
+func (b *DefaultPrivilegeBuilder) SchemaName(s string) *DefaultPrivilegeBuilder {
+	b.schemaName = s
+	return b
+}
+
+func (b *DefaultPrivilegeBuilder) scope() string {
+	q := strings.Builder{}
+	q.WriteString(fmt.Sprintf(`ALTER DEFAULT PRIVILEGES FOR ROLE %s`, quoteIdentifier(b.targetRoleName)))
+
+	if b.schemaName != "" {
+		q.WriteString(fmt.Sprintf(` IN SCHEMA %s`, qualifiedName(b.databaseName, b.schemaName)))
+	} else if b.databaseName != "" {
+		q.WriteString(fmt.Sprintf(` IN DATABASE %s`, quoteIdentifier(b.databaseName)))
+	}
+
+	return q.String()
+}
+
+func (b *DefaultPrivilegeBuilder) Grant() string {
+	return fmt.Sprintf(`%s GRANT %s ON %sS TO %s;`, b.scope(), b.privilege, b.objectType, quoteIdentifier(b.granteeName))
+}
+
+func (b *DefaultPrivilegeBuilder) Revoke() string {
+	return fmt.Sprintf(`%s REVOKE %s ON %sS FROM %s;`, b.scope(), b.privilege, b.objectType, quoteIdentifier(b.granteeName))
+}
+
+func (b *DefaultPrivilegeBuilder) Read() (string, []interface{}) {
+	q := strings.Builder{}
+	q.WriteString(`
+		SELECT
+			targets.id,
+			grantees.id
+		FROM mz_default_privileges
+		JOIN mz_roles AS targets
+			ON mz_default_privileges.role_id = targets.id
+		JOIN mz_roles AS grantees
+			ON mz_default_privileges.grantee = grantees.id
+		LEFT JOIN mz_databases
+			ON mz_default_privileges.database_id = mz_databases.id
+		LEFT JOIN mz_schemas
+			ON mz_default_privileges.schema_id = mz_schemas.id`)
+
+	q.WriteString(`
+		WHERE targets.name = $1
+		AND grantees.name = $2
+		AND mz_default_privileges.object_type = $3
+		AND position($4 IN mz_default_privileges.privileges) > 0`)
+	args := []interface{}{b.targetRoleName, b.granteeName, strings.ToLower(b.objectType), privilegeAbbreviations[b.privilege]}
+
+	if b.databaseName != "" {
+		args = append(args, b.databaseName)
+		q.WriteString(fmt.Sprintf(`
+		AND mz_databases.name = $%d`, len(args)))
+	} else {
+		q.WriteString(`
+		AND mz_default_privileges.database_id IS NULL`)
+	}
+
+	if b.schemaName != "" {
+		args = append(args, b.schemaName)
+		q.WriteString(fmt.Sprintf(`
+		AND mz_schemas.name = $%d`, len(args)))
+	} else {
+		q.WriteString(`
+		AND mz_default_privileges.schema_id IS NULL`)
+	}
+
+	q.WriteString(`;
+	`)
+
+	return q.String(), args
+}
+
+func defaultPrivilegeBuilderFromData(d *schema.ResourceData) *DefaultPrivilegeBuilder {
+	targetRoleName := d.Get("target_role_name").(string)
+	granteeName := d.Get("grantee_name").(string)
+	objectType := d.Get("object_type").(string)
+	privilege := d.Get("privilege").(string)
+
+	builder := newDefaultPrivilegeBuilder(targetRoleName, granteeName, objectType, privilege)
+
+	if v, ok := d.GetOk("database_name"); ok {
+		builder.DatabaseName(v.(string))
+	}
+
+	if v, ok := d.GetOk("schema_name"); ok {
+		builder.SchemaName(v.(string))
+	}
+
+	return builder
+}
+
+func resourceDefaultPrivilegeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	conn := meta.(*ProviderMeta)
+	builder := defaultPrivilegeBuilderFromData(d)
+	q, args := builder.Read()
+
+	var targetId, granteeId string
+
+	// A missing row means the default privilege was revoked
+	if err := queryRow(ctx, conn, q, args, &targetId, &granteeId); err == sql.ErrNoRows {
+		log.Printf("[WARN] default privilege (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return diags
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
+
+	id := []string{"DEFAULT", targetId, granteeId, builder.objectType, builder.databaseName, builder.schemaName, builder.privilege}
+	d.SetId(strings.Join(id, "|"))
+
+	return diags
+}
+
+func resourceDefaultPrivilegeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*ProviderMeta)
+	builder := defaultPrivilegeBuilderFromData(d)
+	q := builder.Grant()
+
+	if diags := ExecResource(ctx, conn, q); diags.HasError() {
+		return diags
+	}
+	return resourceDefaultPrivilegeRead(ctx, d, meta)
+}
+
+func resourceDefaultPrivilegeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*ProviderMeta)
+	builder := defaultPrivilegeBuilderFromData(d)
+	q := builder.Revoke()
+
+	return ExecResource(ctx, conn, q)
+}
+
+// Imports a default privilege with an ID of the form
+// target:grantee:OBJECT_TYPE:PRIVILEGE, optionally followed by
+// :database or :database.schema
+func resourceDefaultPrivilegeImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ":")
+	if len(parts) < 4 || len(parts) > 5 {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected target:grantee:object_type:privilege[:database[.schema]]", d.Id())
+	}
+
+	d.Set("target_role_name", parts[0])
+	d.Set("grantee_name", parts[1])
+	d.Set("object_type", strings.ToUpper(parts[2]))
+	d.Set("privilege", strings.ToUpper(parts[3]))
+
+	if len(parts) == 5 {
+		scope := strings.Split(parts[4], ".")
+		if len(scope) > 2 {
+			return nil, fmt.Errorf("unexpected format of ID (%s), expected target:grantee:object_type:privilege[:database[.schema]]", d.Id())
+		}
+
+		d.Set("database_name", scope[0])
+		if len(scope) == 2 {
+			d.Set("schema_name", scope[1])
+		}
+	}
+
+	return importRead(ctx, d, meta, resourceDefaultPrivilegeRead)
+}
@@ -0,0 +1,55 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceDefaultPrivilegeGrant(t *testing.T) {
+	r := require.New(t)
+	b := newDefaultPrivilegeBuilder("engineer", "analyst", "table", "select")
+	r.Equal(`ALTER DEFAULT PRIVILEGES FOR ROLE "engineer" GRANT SELECT ON TABLES TO "analyst";`, b.Grant())
+}
+
+func TestResourceDefaultPrivilegeGrantDatabase(t *testing.T) {
+	r := require.New(t)
+	b := newDefaultPrivilegeBuilder("engineer", "analyst", "SCHEMA", "CREATE")
+	b.DatabaseName("materialize")
+	r.Equal(`ALTER DEFAULT PRIVILEGES FOR ROLE "engineer" IN DATABASE "materialize" GRANT CREATE ON SCHEMAS TO "analyst";`, b.Grant())
+}
+
+func TestResourceDefaultPrivilegeRevoke(t *testing.T) {
+	r := require.New(t)
+	b := newDefaultPrivilegeBuilder("engineer", "analyst", "TABLE", "SELECT")
+	b.DatabaseName("materialize").SchemaName("public")
+	r.Equal(`ALTER DEFAULT PRIVILEGES FOR ROLE "engineer" IN SCHEMA "materialize"."public" REVOKE SELECT ON TABLES FROM "analyst";`, b.Revoke())
+}
+
+func TestResourceDefaultPrivilegeRead(t *testing.T) {
+	r := require.New(t)
+	b := newDefaultPrivilegeBuilder("engineer", "analyst", "TABLE", "SELECT")
+	b.DatabaseName("materialize").SchemaName("public")
+	q, args := b.Read()
+	r.Equal(`
+		SELECT
+			targets.id,
+			grantees.id
+		FROM mz_default_privileges
+		JOIN mz_roles AS targets
+			ON mz_default_privileges.role_id = targets.id
+		JOIN mz_roles AS grantees
+			ON mz_default_privileges.grantee = grantees.id
+		LEFT JOIN mz_databases
+			ON mz_default_privileges.database_id = mz_databases.id
+		LEFT JOIN mz_schemas
+			ON mz_default_privileges.schema_id = mz_schemas.id
+		WHERE targets.name = $1
+		AND grantees.name = $2
+		AND mz_default_privileges.object_type = $3
+		AND position($4 IN mz_default_privileges.privileges) > 0
+		AND mz_databases.name = $5
+		AND mz_schemas.name = $6;
+	`, q)
+	r.Equal([]interface{}{"engineer", "analyst", "table", "r", "materialize", "public"}, args)
+}
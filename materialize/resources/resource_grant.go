@@ -0,0 +1,260 @@
+package resources
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// The object a privilege is granted on. Databases and clusters are
+// addressed by name alone, schemas by database and name, and every other
+// object by database, schema and name.
+type GrantObject struct {
+	objectType   string
+	name         string
+	schemaName   string
+	databaseName string
+}
+
+func (o GrantObject) QualifiedName() string {
+	switch o.objectType {
+	case "DATABASE", "CLUSTER":
+		return qualifiedName(o.name)
+	case "SCHEMA":
+		return qualifiedName(o.databaseName, o.name)
+	default:
+		return qualifiedName(o.databaseName, o.schemaName, o.name)
+	}
+}
+
+// Views and sources share the privileges of tables
+func (o GrantObject) privilegeObjectType() string {
+	switch o.objectType {
+	case "VIEW", "SOURCE":
+		return "TABLE"
+	default:
+		return o.objectType
+	}
+}
+
+func (o GrantObject) catalogTable() string {
+	return fmt.Sprintf(`mz_%ss`, strings.ToLower(o.objectType))
+}
+
+type PrivilegeBuilder struct {
+	roleName  string
+	privilege string
+	object    GrantObject
+}
+
+func newPrivilegeBuilder(roleName, privilege string, object GrantObject) *PrivilegeBuilder {
+	return &PrivilegeBuilder{
+		roleName:  roleName,
+		privilege: strings.ToUpper(privilege),
+		object:    object,
+	}
+}
+
+func (b *PrivilegeBuilder) Grant() string {
+	return fmt.Sprintf(`GRANT %s ON %s %s TO %s;`, b.privilege, b.object.privilegeObjectType(), b.object.QualifiedName(), quoteIdentifier(b.roleName))
+}
+
+func (b *PrivilegeBuilder) Revoke() string {
+	return fmt.Sprintf(`REVOKE %s ON %s %s FROM %s;`, b.privilege, b.object.privilegeObjectType(), b.object.QualifiedName(), quoteIdentifier(b.roleName))
+}
+
+func (b *PrivilegeBuilder) Read() (string, []interface{}) {
+	t := b.object.catalogTable()
+
+	q := strings.Builder{}
+	q.WriteString(fmt.Sprintf(`
+		SELECT
+			%[1]s.id,
+			mz_roles.id
+		FROM %[1]s`, t))
+
+	var w []string
+	var args []interface{}
+	where := func(column string, value interface{}) {
+		args = append(args, value)
+		w = append(w, fmt.Sprintf(`%s = $%d`, column, len(args)))
+	}
+
+	where(fmt.Sprintf(`%s.name`, t), b.object.name)
+
+	switch b.object.objectType {
+	case "DATABASE", "CLUSTER":
+	case "SCHEMA":
+		q.WriteString(`
+		JOIN mz_databases
+			ON mz_schemas.database_id = mz_databases.id`)
+		where(`mz_databases.name`, b.object.databaseName)
+	default:
+		q.WriteString(fmt.Sprintf(`
+		JOIN mz_schemas
+			ON %s.schema_id = mz_schemas.id
+		JOIN mz_databases
+			ON mz_schemas.database_id = mz_databases.id`, t))
+		where(`mz_schemas.name`, b.object.schemaName)
+		where(`mz_databases.name`, b.object.databaseName)
+	}
+
+	q.WriteString(fmt.Sprintf(`
+		CROSS JOIN LATERAL mz_internal.mz_aclexplode(%s.privileges) AS privileges
+		JOIN mz_roles
+			ON privileges.grantee = mz_roles.id`, t))
+	where(`mz_roles.name`, b.roleName)
+	where(`privileges.privilege_type`, b.privilege)
+
+	q.WriteString(fmt.Sprintf(`
+		WHERE %s;
+	`, strings.Join(w[:], "\n\t\tAND ")))
+	return q.String(), args
+}
+
+func grantObjectFromData(objectType string, d *schema.ResourceData) GrantObject {
+	o := GrantObject{objectType: objectType}
+
+	switch objectType {
+	case "DATABASE":
+		o.name = d.Get("database_name").(string)
+	case "CLUSTER":
+		o.name = d.Get("cluster_name").(string)
+	case "SCHEMA":
+		o.name = d.Get("schema_name").(string)
+		o.databaseName = d.Get("database_name").(string)
+	default:
+		o.name = d.Get(fmt.Sprintf("%s_name", strings.ToLower(objectType))).(string)
+		o.schemaName = d.Get("schema_name").(string)
+		o.databaseName = d.Get("database_name").(string)
+	}
+
+	return o
+}
+
+func resourceGrantRead(ctx context.Context, d *schema.ResourceData, meta interface{}, objectType string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	conn := meta.(*ProviderMeta)
+	roleName := d.Get("role_name").(string)
+	privilege := d.Get("privilege").(string)
+
+	builder := newPrivilegeBuilder(roleName, privilege, grantObjectFromData(objectType, d))
+	q, args := builder.Read()
+
+	var objectId, roleId string
+
+	// A missing row means the privilege was revoked
+	if err := queryRow(ctx, conn, q, args, &objectId, &roleId); err == sql.ErrNoRows {
+		log.Printf("[WARN] grant (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return diags
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("GRANT|%s|%s|%s", objectId, roleId, builder.privilege))
+
+	return diags
+}
+
+func resourceGrantCreate(ctx context.Context, d *schema.ResourceData, meta interface{}, objectType string) diag.Diagnostics {
+	conn := meta.(*ProviderMeta)
+	roleName := d.Get("role_name").(string)
+	privilege := d.Get("privilege").(string)
+
+	builder := newPrivilegeBuilder(roleName, privilege, grantObjectFromData(objectType, d))
+	q := builder.Grant()
+
+	if diags := ExecResource(ctx, conn, q); diags.HasError() {
+		return diags
+	}
+	return resourceGrantRead(ctx, d, meta, objectType)
+}
+
+func resourceGrantDelete(ctx context.Context, d *schema.ResourceData, meta interface{}, objectType string) diag.Diagnostics {
+	conn := meta.(*ProviderMeta)
+	roleName := d.Get("role_name").(string)
+	privilege := d.Get("privilege").(string)
+
+	builder := newPrivilegeBuilder(roleName, privilege, grantObjectFromData(objectType, d))
+	q := builder.Revoke()
+
+	return ExecResource(ctx, conn, q)
+}
+
+// Qualified name attributes of the object for each object type
+func grantObjectAttributes(objectType string) []string {
+	switch objectType {
+	case "DATABASE":
+		return []string{"database_name"}
+	case "CLUSTER":
+		return []string{"cluster_name"}
+	case "SCHEMA":
+		return []string{"database_name", "schema_name"}
+	default:
+		return []string{"database_name", "schema_name", fmt.Sprintf("%s_name", strings.ToLower(objectType))}
+	}
+}
+
+// Imports a grant with an ID of the form role:PRIVILEGE:qualified.object
+func resourceGrantImport(objectType string) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		attributes := grantObjectAttributes(objectType)
+
+		parts := strings.SplitN(d.Id(), ":", 3)
+		if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("unexpected format of ID (%s), expected role:privilege:%s", d.Id(), strings.Join(attributes, "."))
+		}
+
+		names := splitImportName(parts[2])
+		if len(names) != len(attributes) {
+			return nil, fmt.Errorf("unexpected format of ID (%s), expected role:privilege:%s", d.Id(), strings.Join(attributes, "."))
+		}
+
+		d.Set("role_name", parts[0])
+		d.Set("privilege", strings.ToUpper(parts[1]))
+		for i, a := range attributes {
+			d.Set(a, names[i])
+		}
+
+		read := func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return resourceGrantRead(ctx, d, meta, objectType)
+		}
+		return importRead(ctx, d, meta, read)
+	}
+}
+
+// Attributes shared by every grant resource
+func grantSchema(privileges []string, objectSchema map[string]*schema.Schema) map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"role_name": {
+			Description: "The name of the role to grant the privilege to.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"privilege": {
+			Description:  fmt.Sprintf("The privilege to grant. One of %s.", strings.Join(privileges, ", ")),
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringInSlice(privileges, true),
+			StateFunc: func(v interface{}) string {
+				return strings.ToUpper(v.(string))
+			},
+		},
+	}
+
+	for k, v := range objectSchema {
+		s[k] = v
+	}
+
+	return s
+}
@@ -0,0 +1,45 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func GrantCluster() *schema.Resource {
+	return importDefaults(&schema.Resource{
+		Description: "Manages a privilege on a cluster granted to a role.",
+
+		CreateContext: resourceGrantClusterCreate,
+		ReadContext:   resourceGrantClusterRead,
+		DeleteContext: resourceGrantClusterDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGrantImport("CLUSTER"),
+		},
+
+		Timeouts: defaultTimeouts(),
+
+		Schema: grantSchema(clusterPrivileges, map[string]*schema.Schema{
+			"cluster_name": {
+				Description: "The cluster that the privilege is granted on.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+		}),
+	})
+}
+
+func resourceGrantClusterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceGrantRead(ctx, d, meta, "CLUSTER")
+}
+
+func resourceGrantClusterCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceGrantCreate(ctx, d, meta, "CLUSTER")
+}
+
+func resourceGrantClusterDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceGrantDelete(ctx, d, meta, "CLUSTER")
+}
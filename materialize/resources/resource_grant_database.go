@@ -0,0 +1,45 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func GrantDatabase() *schema.Resource {
+	return importDefaults(&schema.Resource{
+		Description: "Manages a privilege on a database granted to a role.",
+
+		CreateContext: resourceGrantDatabaseCreate,
+		ReadContext:   resourceGrantDatabaseRead,
+		DeleteContext: resourceGrantDatabaseDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGrantImport("DATABASE"),
+		},
+
+		Timeouts: defaultTimeouts(),
+
+		Schema: grantSchema(databasePrivileges, map[string]*schema.Schema{
+			"database_name": {
+				Description: "The database that the privilege is granted on.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+		}),
+	})
+}
+
+func resourceGrantDatabaseRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceGrantRead(ctx, d, meta, "DATABASE")
+}
+
+func resourceGrantDatabaseCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceGrantCreate(ctx, d, meta, "DATABASE")
+}
+
+func resourceGrantDatabaseDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceGrantDelete(ctx, d, meta, "DATABASE")
+}
@@ -0,0 +1,54 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func GrantSchema() *schema.Resource {
+	return importDefaults(&schema.Resource{
+		Description: "Manages a privilege on a schema granted to a role.",
+
+		CreateContext: resourceGrantSchemaCreate,
+		ReadContext:   resourceGrantSchemaRead,
+		DeleteContext: resourceGrantSchemaDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGrantImport("SCHEMA"),
+		},
+
+		Timeouts: defaultTimeouts(),
+
+		CustomizeDiff: defaultDatabaseName,
+
+		Schema: grantSchema(schemaPrivileges, map[string]*schema.Schema{
+			"schema_name": {
+				Description: "The schema that the privilege is granted on.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"database_name": {
+				Description: "The database of the schema. Defaults to the database of the provider configuration.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+		}),
+	})
+}
+
+func resourceGrantSchemaRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceGrantRead(ctx, d, meta, "SCHEMA")
+}
+
+func resourceGrantSchemaCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceGrantCreate(ctx, d, meta, "SCHEMA")
+}
+
+func resourceGrantSchemaDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceGrantDelete(ctx, d, meta, "SCHEMA")
+}
@@ -0,0 +1,61 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func GrantSource() *schema.Resource {
+	return importDefaults(&schema.Resource{
+		Description: "Manages a privilege on a source granted to a role.",
+
+		CreateContext: resourceGrantSourceCreate,
+		ReadContext:   resourceGrantSourceRead,
+		DeleteContext: resourceGrantSourceDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGrantImport("SOURCE"),
+		},
+
+		Timeouts: defaultTimeouts(),
+
+		CustomizeDiff: defaultDatabaseName,
+
+		Schema: grantSchema(sourcePrivileges, map[string]*schema.Schema{
+			"source_name": {
+				Description: "The source that the privilege is granted on.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"schema_name": {
+				Description: "The schema of the source.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "public",
+			},
+			"database_name": {
+				Description: "The database of the source. Defaults to the database of the provider configuration.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+		}),
+	})
+}
+
+func resourceGrantSourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceGrantRead(ctx, d, meta, "SOURCE")
+}
+
+func resourceGrantSourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceGrantCreate(ctx, d, meta, "SOURCE")
+}
+
+func resourceGrantSourceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceGrantDelete(ctx, d, meta, "SOURCE")
+}
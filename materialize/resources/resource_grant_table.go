@@ -0,0 +1,61 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func GrantTable() *schema.Resource {
+	return importDefaults(&schema.Resource{
+		Description: "Manages a privilege on a table granted to a role.",
+
+		CreateContext: resourceGrantTableCreate,
+		ReadContext:   resourceGrantTableRead,
+		DeleteContext: resourceGrantTableDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGrantImport("TABLE"),
+		},
+
+		Timeouts: defaultTimeouts(),
+
+		CustomizeDiff: defaultDatabaseName,
+
+		Schema: grantSchema(tablePrivileges, map[string]*schema.Schema{
+			"table_name": {
+				Description: "The table that the privilege is granted on.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"schema_name": {
+				Description: "The schema of the table.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "public",
+			},
+			"database_name": {
+				Description: "The database of the table. Defaults to the database of the provider configuration.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+		}),
+	})
+}
+
+func resourceGrantTableRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceGrantRead(ctx, d, meta, "TABLE")
+}
+
+func resourceGrantTableCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceGrantCreate(ctx, d, meta, "TABLE")
+}
+
+func resourceGrantTableDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceGrantDelete(ctx, d, meta, "TABLE")
+}
@@ -0,0 +1,143 @@
+package resources
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceGrantDatabase(t *testing.T) {
+	r := require.New(t)
+	o := GrantObject{objectType: "DATABASE", name: "database"}
+	b := newPrivilegeBuilder("role", "USAGE", o)
+	r.Equal(`GRANT USAGE ON DATABASE "database" TO "role";`, b.Grant())
+	r.Equal(`REVOKE USAGE ON DATABASE "database" FROM "role";`, b.Revoke())
+	q, args := b.Read()
+	r.Equal(`
+		SELECT
+			mz_databases.id,
+			mz_roles.id
+		FROM mz_databases
+		CROSS JOIN LATERAL mz_internal.mz_aclexplode(mz_databases.privileges) AS privileges
+		JOIN mz_roles
+			ON privileges.grantee = mz_roles.id
+		WHERE mz_databases.name = $1
+		AND mz_roles.name = $2
+		AND privileges.privilege_type = $3;
+	`, q)
+	r.Equal([]interface{}{"database", "role", "USAGE"}, args)
+}
+
+func TestResourceGrantSchema(t *testing.T) {
+	r := require.New(t)
+	o := GrantObject{objectType: "SCHEMA", name: "schema", databaseName: "database"}
+	b := newPrivilegeBuilder("role", "CREATE", o)
+	r.Equal(`GRANT CREATE ON SCHEMA "database"."schema" TO "role";`, b.Grant())
+	r.Equal(`REVOKE CREATE ON SCHEMA "database"."schema" FROM "role";`, b.Revoke())
+	q, args := b.Read()
+	r.Equal(`
+		SELECT
+			mz_schemas.id,
+			mz_roles.id
+		FROM mz_schemas
+		JOIN mz_databases
+			ON mz_schemas.database_id = mz_databases.id
+		CROSS JOIN LATERAL mz_internal.mz_aclexplode(mz_schemas.privileges) AS privileges
+		JOIN mz_roles
+			ON privileges.grantee = mz_roles.id
+		WHERE mz_schemas.name = $1
+		AND mz_databases.name = $2
+		AND mz_roles.name = $3
+		AND privileges.privilege_type = $4;
+	`, q)
+	r.Equal([]interface{}{"schema", "database", "role", "CREATE"}, args)
+}
+
+func TestResourceGrantCluster(t *testing.T) {
+	r := require.New(t)
+	o := GrantObject{objectType: "CLUSTER", name: "cluster"}
+	b := newPrivilegeBuilder("role", "USAGE", o)
+	r.Equal(`GRANT USAGE ON CLUSTER "cluster" TO "role";`, b.Grant())
+	r.Equal(`REVOKE USAGE ON CLUSTER "cluster" FROM "role";`, b.Revoke())
+}
+
+func TestResourceGrantTable(t *testing.T) {
+	r := require.New(t)
+	o := GrantObject{objectType: "TABLE", name: "table", schemaName: "schema", databaseName: "database"}
+	b := newPrivilegeBuilder("role", "INSERT", o)
+	r.Equal(`GRANT INSERT ON TABLE "database"."schema"."table" TO "role";`, b.Grant())
+	r.Equal(`REVOKE INSERT ON TABLE "database"."schema"."table" FROM "role";`, b.Revoke())
+	q, args := b.Read()
+	r.Equal(`
+		SELECT
+			mz_tables.id,
+			mz_roles.id
+		FROM mz_tables
+		JOIN mz_schemas
+			ON mz_tables.schema_id = mz_schemas.id
+		JOIN mz_databases
+			ON mz_schemas.database_id = mz_databases.id
+		CROSS JOIN LATERAL mz_internal.mz_aclexplode(mz_tables.privileges) AS privileges
+		JOIN mz_roles
+			ON privileges.grantee = mz_roles.id
+		WHERE mz_tables.name = $1
+		AND mz_schemas.name = $2
+		AND mz_databases.name = $3
+		AND mz_roles.name = $4
+		AND privileges.privilege_type = $5;
+	`, q)
+	r.Equal([]interface{}{"table", "schema", "database", "role", "INSERT"}, args)
+}
+
+func TestResourceGrantView(t *testing.T) {
+	r := require.New(t)
+	o := GrantObject{objectType: "VIEW", name: "view", schemaName: "schema", databaseName: "database"}
+	b := newPrivilegeBuilder("role", "SELECT", o)
+	r.Equal(`GRANT SELECT ON TABLE "database"."schema"."view" TO "role";`, b.Grant())
+	r.Equal(`REVOKE SELECT ON TABLE "database"."schema"."view" FROM "role";`, b.Revoke())
+}
+
+func TestResourceGrantSource(t *testing.T) {
+	r := require.New(t)
+	o := GrantObject{objectType: "SOURCE", name: "source", schemaName: "schema", databaseName: "database"}
+	b := newPrivilegeBuilder("role", "SELECT", o)
+	r.Equal(`GRANT SELECT ON TABLE "database"."schema"."source" TO "role";`, b.Grant())
+	r.Equal(`REVOKE SELECT ON TABLE "database"."schema"."source" FROM "role";`, b.Revoke())
+}
+
+func TestResourceGrantLowercasePrivilege(t *testing.T) {
+	r := require.New(t)
+	o := GrantObject{objectType: "DATABASE", name: "database"}
+	b := newPrivilegeBuilder("role", "usage", o)
+	r.Equal(`GRANT USAGE ON DATABASE "database" TO "role";`, b.Grant())
+	_, args := b.Read()
+	r.Equal([]interface{}{"database", "role", "USAGE"}, args)
+}
+
+func TestResourceGrantCreateLowercasePrivilege(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.MatchExpectationsInOrder(true)
+		mock.ExpectExec(`GRANT SELECT ON TABLE "materialize"."public"."table" TO "role";`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery(`SELECT\s+mz_tables.id`).WithArgs("table", "public", "materialize", "role", "SELECT").WillReturnRows(
+			sqlmock.NewRows([]string{"id", "role_id"}).AddRow("u1", "u2"),
+		)
+
+		d := schema.TestResourceDataRaw(t, GrantTable().Schema, map[string]interface{}{
+			"role_name":     "role",
+			"privilege":     "select",
+			"table_name":    "table",
+			"schema_name":   "public",
+			"database_name": "materialize",
+		})
+
+		diags := resourceGrantTableCreate(context.TODO(), d, testMeta(db))
+		r.False(diags.HasError(), "%v", diags)
+		r.Equal("GRANT|u1|u2|SELECT", d.Id())
+	})
+}
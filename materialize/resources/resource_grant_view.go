@@ -0,0 +1,61 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func GrantView() *schema.Resource {
+	return importDefaults(&schema.Resource{
+		Description: "Manages a privilege on a view granted to a role.",
+
+		CreateContext: resourceGrantViewCreate,
+		ReadContext:   resourceGrantViewRead,
+		DeleteContext: resourceGrantViewDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGrantImport("VIEW"),
+		},
+
+		Timeouts: defaultTimeouts(),
+
+		CustomizeDiff: defaultDatabaseName,
+
+		Schema: grantSchema(viewPrivileges, map[string]*schema.Schema{
+			"view_name": {
+				Description: "The view that the privilege is granted on.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"schema_name": {
+				Description: "The schema of the view.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "public",
+			},
+			"database_name": {
+				Description: "The database of the view. Defaults to the database of the provider configuration.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+		}),
+	})
+}
+
+func resourceGrantViewRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceGrantRead(ctx, d, meta, "VIEW")
+}
+
+func resourceGrantViewCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceGrantCreate(ctx, d, meta, "VIEW")
+}
+
+func resourceGrantViewDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceGrantDelete(ctx, d, meta, "VIEW")
+}
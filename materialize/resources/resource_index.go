@@ -0,0 +1,341 @@
+package resources
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func Index() *schema.Resource {
+	return importDefaults(&schema.Resource{
+		Description: "An in-memory index on a source, view, or materialized view.",
+
+		CreateContext: resourceIndexCreate,
+		ReadContext:   resourceIndexRead,
+		UpdateContext: resourceIndexUpdate,
+		DeleteContext: resourceIndexDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importQualifiedName(resourceIndexRead, "database_name", "schema_name", "name"),
+		},
+
+		Timeouts: defaultTimeouts(),
+
+		CustomizeDiff: defaultDatabaseName,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description:   "The identifier for the index. If not specified, a name is generated from the object and key columns.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"default"},
+			},
+			"obj_name": {
+				Description: "The name of the source, view, or materialized view on which you want to create an index.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"schema_name": {
+				Description: "The identifier for the schema of the indexed object.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "public",
+			},
+			"database_name": {
+				Description: "The identifier for the database of the indexed object. Defaults to the database of the provider configuration.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"cluster_name": {
+				Description: "The cluster to maintain this index. If not specified, defaults to the active cluster.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"default": {
+				Description:   "Create a default index using all inferred columns as the key. Default indexes are always named after the indexed object, so name cannot be set.",
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ForceNew:      true,
+				ExactlyOneOf:  []string{"default", "col_expr"},
+				ConflictsWith: []string{"name"},
+			},
+			"col_expr": {
+				Description: "The expressions to use as the key for the index.",
+				Type:        schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"default", "col_expr"},
+			},
+			"key_columns": {
+				Description: "The key of the index as stored in the catalog.",
+				Type:        schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Computed: true,
+			},
+		},
+	})
+}
+
+type IndexBuilder struct {
+	indexName    string
+	objName      string
+	schemaName   string
+	databaseName string
+	clusterName  string
+	defaultIndex bool
+	colExpr      []string
+}
+
+func newIndexBuilder(indexName, objName, schemaName, databaseName string) *IndexBuilder {
+	return &IndexBuilder{
+		indexName:    indexName,
+		objName:      objName,
+		schemaName:   schemaName,
+		databaseName: databaseName,
+	}
+}
+
+func (b *IndexBuilder) ClusterName(c string) *IndexBuilder {
+	b.clusterName = c
+	return b
+}
+
+func (b *IndexBuilder) DefaultIndex() *IndexBuilder {
+	b.defaultIndex = true
+	return b
+}
+
+func (b *IndexBuilder) ColExpr(c []string) *IndexBuilder {
+	b.colExpr = c
+	return b
+}
+
+var nonIdentifierChars = regexp.MustCompile(`[^a-zA-Z0-9_]+`)
+
+// IndexName returns the configured name, or the name Materialize would
+// generate for the index when none is given
+func (b *IndexBuilder) IndexName() string {
+	if b.indexName != "" {
+		return b.indexName
+	}
+
+	if b.defaultIndex {
+		return fmt.Sprintf(`%s_primary_idx`, b.objName)
+	}
+
+	n := []string{b.objName}
+	for _, c := range b.colExpr {
+		n = append(n, strings.Trim(nonIdentifierChars.ReplaceAllString(c, "_"), "_"))
+	}
+	n = append(n, "idx")
+	return strings.Join(n[:], "_")
+}
+
+func (b *IndexBuilder) Create() string {
+	q := strings.Builder{}
+
+	if b.defaultIndex {
+		q.WriteString(`CREATE DEFAULT INDEX`)
+	} else {
+		q.WriteString(fmt.Sprintf(`CREATE INDEX %s`, quoteIdentifier(b.IndexName())))
+	}
+
+	if b.clusterName != "" {
+		q.WriteString(fmt.Sprintf(` IN CLUSTER %s`, quoteIdentifier(b.clusterName)))
+	}
+
+	q.WriteString(fmt.Sprintf(` ON %s`, qualifiedName(b.databaseName, b.schemaName, b.objName)))
+
+	if !b.defaultIndex {
+		q.WriteString(fmt.Sprintf(` (%s)`, strings.Join(b.colExpr[:], ", ")))
+	}
+
+	q.WriteString(`;`)
+	return q.String()
+}
+
+func (b *IndexBuilder) Read() (string, []interface{}) {
+	return `
+		SELECT
+			mz_indexes.id,
+			mz_indexes.name,
+			mz_objects.name,
+			mz_clusters.name
+		FROM mz_indexes
+		JOIN mz_objects
+			ON mz_indexes.on_id = mz_objects.id
+		JOIN mz_schemas
+			ON mz_objects.schema_id = mz_schemas.id
+		JOIN mz_databases
+			ON mz_schemas.database_id = mz_databases.id
+		JOIN mz_clusters
+			ON mz_indexes.cluster_id = mz_clusters.id
+		WHERE mz_indexes.name = $1
+		AND mz_schemas.name = $2
+		AND mz_databases.name = $3;
+	`, []interface{}{b.IndexName(), b.schemaName, b.databaseName}
+}
+
+func (b *IndexBuilder) ReadColumns(indexId string) (string, []interface{}) {
+	return `
+		SELECT COALESCE(mz_columns.name, mz_index_columns.on_expression)
+		FROM mz_index_columns
+		JOIN mz_indexes
+			ON mz_index_columns.index_id = mz_indexes.id
+		LEFT JOIN mz_columns
+			ON mz_indexes.on_id = mz_columns.id
+			AND mz_index_columns.on_position = mz_columns.position
+		WHERE mz_index_columns.index_id = $1
+		ORDER BY mz_index_columns.index_position;
+	`, []interface{}{indexId}
+}
+
+func (b *IndexBuilder) Rename(newName string) string {
+	return fmt.Sprintf(`ALTER INDEX %s RENAME TO %s;`, qualifiedName(b.databaseName, b.schemaName, b.IndexName()), quoteIdentifier(newName))
+}
+
+func (b *IndexBuilder) Drop() string {
+	return fmt.Sprintf(`DROP INDEX %s;`, qualifiedName(b.databaseName, b.schemaName, b.IndexName()))
+}
+
+func resourceIndexRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	conn := meta.(*ProviderMeta)
+	indexName := d.Get("name").(string)
+	objName := d.Get("obj_name").(string)
+	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
+
+	builder := newIndexBuilder(indexName, objName, schemaName, databaseName)
+	q, args := builder.Read()
+
+	var id, name, obj, cluster string
+	if err := queryRow(ctx, conn, q, args, &id, &name, &obj, &cluster); err == sql.ErrNoRows {
+		log.Printf("[WARN] index (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return diags
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id)
+	d.Set("name", name)
+	d.Set("obj_name", obj)
+	d.Set("cluster_name", cluster)
+
+	q, args = builder.ReadColumns(id)
+	rows, err := QueryRows(ctx, conn, q, args)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer rows.Close()
+
+	var columns []string
+	for rows.Next() {
+		var c string
+		if err := rows.Scan(&c); err != nil {
+			return diag.FromErr(err)
+		}
+		columns = append(columns, c)
+	}
+	d.Set("key_columns", columns)
+
+	// Imported indexes are matched to the default index naming to decide
+	// whether they were created as default indexes
+	_, hasDefault := d.GetOk("default")
+	_, hasColExpr := d.GetOk("col_expr")
+	if !hasDefault && !hasColExpr {
+		if name == fmt.Sprintf(`%s_primary_idx`, obj) {
+			d.Set("default", true)
+		} else {
+			d.Set("col_expr", columns)
+		}
+	}
+
+	return diags
+}
+
+func resourceIndexCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*ProviderMeta)
+
+	indexName := d.Get("name").(string)
+	objName := d.Get("obj_name").(string)
+	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
+
+	builder := newIndexBuilder(indexName, objName, schemaName, databaseName)
+
+	if v, ok := d.GetOk("cluster_name"); ok {
+		builder.ClusterName(v.(string))
+	}
+
+	if v, ok := d.GetOk("default"); ok && v.(bool) {
+		builder.DefaultIndex()
+	}
+
+	if v, ok := d.GetOk("col_expr"); ok {
+		builder.ColExpr(sliceOfStrings(v))
+	}
+
+	q := builder.Create()
+
+	if diags := ExecResource(ctx, conn, q); diags.HasError() {
+		return diags
+	}
+
+	// Read looks the index up by name, which may have been generated
+	d.Set("name", builder.IndexName())
+	return resourceIndexRead(ctx, d, meta)
+}
+
+func resourceIndexUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*ProviderMeta)
+	objName := d.Get("obj_name").(string)
+	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
+
+	if d.HasChange("name") {
+		oldName, newName := d.GetChange("name")
+
+		builder := newIndexBuilder(oldName.(string), objName, schemaName, databaseName)
+		q := builder.Rename(newName.(string))
+
+		if diags := ExecResource(ctx, conn, q); diags.HasError() {
+			return diags
+		}
+	}
+
+	return resourceIndexRead(ctx, d, meta)
+}
+
+func resourceIndexDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*ProviderMeta)
+	indexName := d.Get("name").(string)
+	objName := d.Get("obj_name").(string)
+	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
+
+	builder := newIndexBuilder(indexName, objName, schemaName, databaseName)
+	q := builder.Drop()
+
+	return ExecResource(ctx, conn, q)
+}
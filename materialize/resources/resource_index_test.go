@@ -0,0 +1,105 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceIndexCreate(t *testing.T) {
+	r := require.New(t)
+	b := newIndexBuilder("index", "source", "schema", "database")
+	b.ClusterName("cluster")
+	b.ColExpr([]string{"column"})
+	r.Equal(`CREATE INDEX "index" IN CLUSTER "cluster" ON "database"."schema"."source" (column);`, b.Create())
+}
+
+func TestResourceIndexCreateGeneratedName(t *testing.T) {
+	r := require.New(t)
+	b := newIndexBuilder("", "source", "schema", "database")
+	b.ColExpr([]string{"a", "upper(b)"})
+	r.Equal(`CREATE INDEX "source_a_upper_b_idx" ON "database"."schema"."source" (a, upper(b));`, b.Create())
+}
+
+func TestResourceIndexCreateDefault(t *testing.T) {
+	r := require.New(t)
+	b := newIndexBuilder("", "source", "schema", "database")
+	b.ClusterName("cluster")
+	b.DefaultIndex()
+	r.Equal(`CREATE DEFAULT INDEX IN CLUSTER "cluster" ON "database"."schema"."source";`, b.Create())
+	r.Equal(`source_primary_idx`, b.IndexName())
+}
+
+func TestResourceIndexRead(t *testing.T) {
+	r := require.New(t)
+	b := newIndexBuilder("index", "source", "schema", "database")
+	q, args := b.Read()
+	r.Equal(`
+		SELECT
+			mz_indexes.id,
+			mz_indexes.name,
+			mz_objects.name,
+			mz_clusters.name
+		FROM mz_indexes
+		JOIN mz_objects
+			ON mz_indexes.on_id = mz_objects.id
+		JOIN mz_schemas
+			ON mz_objects.schema_id = mz_schemas.id
+		JOIN mz_databases
+			ON mz_schemas.database_id = mz_databases.id
+		JOIN mz_clusters
+			ON mz_indexes.cluster_id = mz_clusters.id
+		WHERE mz_indexes.name = $1
+		AND mz_schemas.name = $2
+		AND mz_databases.name = $3;
+	`, q)
+	r.Equal([]interface{}{"index", "schema", "database"}, args)
+}
+
+func TestResourceIndexReadColumns(t *testing.T) {
+	r := require.New(t)
+	b := newIndexBuilder("index", "source", "schema", "database")
+	q, args := b.ReadColumns("u1")
+	r.Equal(`
+		SELECT COALESCE(mz_columns.name, mz_index_columns.on_expression)
+		FROM mz_index_columns
+		JOIN mz_indexes
+			ON mz_index_columns.index_id = mz_indexes.id
+		LEFT JOIN mz_columns
+			ON mz_indexes.on_id = mz_columns.id
+			AND mz_index_columns.on_position = mz_columns.position
+		WHERE mz_index_columns.index_id = $1
+		ORDER BY mz_index_columns.index_position;
+	`, q)
+	r.Equal([]interface{}{"u1"}, args)
+}
+
+func TestResourceIndexRename(t *testing.T) {
+	r := require.New(t)
+	b := newIndexBuilder("index", "source", "schema", "database")
+	r.Equal(`ALTER INDEX "database"."schema"."index" RENAME TO "new_index";`, b.Rename("new_index"))
+}
+
+func TestResourceIndexDrop(t *testing.T) {
+	r := require.New(t)
+	b := newIndexBuilder("index", "source", "schema", "database")
+	r.Equal(`DROP INDEX "database"."schema"."index";`, b.Drop())
+}
+
+func TestResourceIndexDefaultConflictsWithName(t *testing.T) {
+	r := require.New(t)
+
+	diags := Index().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":     "index",
+		"obj_name": "source",
+		"default":  true,
+	}))
+	r.True(diags.HasError())
+
+	diags = Index().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"obj_name": "source",
+		"default":  true,
+	}))
+	r.False(diags.HasError())
+}
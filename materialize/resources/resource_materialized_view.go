@@ -0,0 +1,236 @@
+package resources
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func MaterializedView() *schema.Resource {
+	return importDefaults(&schema.Resource{
+		Description: "A materialized view, which persists the results of a query and incrementally updates them as new data arrives.",
+
+		CreateContext: resourceMaterializedViewCreate,
+		ReadContext:   resourceMaterializedViewRead,
+		UpdateContext: resourceMaterializedViewUpdate,
+		DeleteContext: resourceMaterializedViewDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importQualifiedName(resourceMaterializedViewRead, "database_name", "schema_name", "name"),
+		},
+
+		Timeouts: defaultTimeouts(),
+
+		CustomizeDiff: defaultDatabaseName,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "The identifier for the materialized view.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"schema_name": {
+				Description: "The identifier for the materialized view schema.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "public",
+			},
+			"database_name": {
+				Description: "The identifier for the materialized view database. Defaults to the database of the provider configuration.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"cluster_name": {
+				Description: "The cluster to maintain the materialized view. If not specified, defaults to the active cluster.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"statement": {
+				Description:      "The SQL statement to create the materialized view.",
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: suppressStatementDiff,
+				ForceNew:         true,
+			},
+			"definition": {
+				Description: "The materialized view definition as stored in the catalog.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	})
+}
+
+type MaterializedViewBuilder struct {
+	materializedViewName string
+	schemaName           string
+	databaseName         string
+	clusterName          string
+	statement            string
+}
+
+func newMaterializedViewBuilder(materializedViewName, schemaName, databaseName string) *MaterializedViewBuilder {
+	return &MaterializedViewBuilder{
+		materializedViewName: materializedViewName,
+		schemaName:           schemaName,
+		databaseName:         databaseName,
+	}
+}
+
+func (b *MaterializedViewBuilder) ClusterName(c string) *MaterializedViewBuilder {
+	b.clusterName = c
+	return b
+}
+
+func (b *MaterializedViewBuilder) Statement(s string) *MaterializedViewBuilder {
+	// Statements commonly end with a semicolon, which would terminate the DDL early
+	b.statement = strings.TrimSuffix(strings.TrimSpace(s), ";")
+	return b
+}
+
+func (b *MaterializedViewBuilder) Create() string {
+	q := strings.Builder{}
+	q.WriteString(fmt.Sprintf(`CREATE MATERIALIZED VIEW %s`, qualifiedName(b.databaseName, b.schemaName, b.materializedViewName)))
+
+	if b.clusterName != "" {
+		q.WriteString(fmt.Sprintf(` IN CLUSTER %s`, quoteIdentifier(b.clusterName)))
+	}
+
+	q.WriteString(fmt.Sprintf(` AS %s;`, b.statement))
+	return q.String()
+}
+
+func (b *MaterializedViewBuilder) Read() (string, []interface{}) {
+	return `
+		SELECT
+			mz_materialized_views.id,
+			mz_materialized_views.name,
+			mz_schemas.name,
+			mz_databases.name,
+			mz_clusters.name,
+			mz_materialized_views.definition
+		FROM mz_materialized_views
+		JOIN mz_schemas
+			ON mz_materialized_views.schema_id = mz_schemas.id
+		JOIN mz_databases
+			ON mz_schemas.database_id = mz_databases.id
+		JOIN mz_clusters
+			ON mz_materialized_views.cluster_id = mz_clusters.id
+		WHERE mz_materialized_views.name = $1
+		AND mz_schemas.name = $2
+		AND mz_databases.name = $3;
+	`, []interface{}{b.materializedViewName, b.schemaName, b.databaseName}
+}
+
+func (b *MaterializedViewBuilder) Rename(newName string) string {
+	return fmt.Sprintf(`ALTER MATERIALIZED VIEW %s RENAME TO %s;`, qualifiedName(b.databaseName, b.schemaName, b.materializedViewName), quoteIdentifier(newName))
+}
+
+func (b *MaterializedViewBuilder) Drop() string {
+	return fmt.Sprintf(`DROP MATERIALIZED VIEW %s;`, qualifiedName(b.databaseName, b.schemaName, b.materializedViewName))
+}
+
+func resourceMaterializedViewRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	conn := meta.(*ProviderMeta)
+	materializedViewName := d.Get("name").(string)
+	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
+
+	builder := newMaterializedViewBuilder(materializedViewName, schemaName, databaseName)
+	q, args := builder.Read()
+
+	var id, name, schema, database, cluster, definition string
+	if err := queryRow(ctx, conn, q, args, &id, &name, &schema, &database, &cluster, &definition); err == sql.ErrNoRows {
+		log.Printf("[WARN] materialized view (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return diags
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id)
+	d.Set("name", name)
+	d.Set("schema_name", schema)
+	d.Set("database_name", database)
+	d.Set("cluster_name", cluster)
+
+	// The catalog stores a normalized definition, so compare against the
+	// definition recorded at creation rather than the configured statement
+	if v, ok := d.GetOk("definition"); ok && v.(string) != definition {
+		d.Set("statement", definition)
+	} else if _, ok := d.GetOk("statement"); !ok {
+		// Imported materialized views take their statement from the catalog
+		d.Set("statement", definition)
+	}
+	d.Set("definition", definition)
+
+	return diags
+}
+
+func resourceMaterializedViewCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*ProviderMeta)
+
+	materializedViewName := d.Get("name").(string)
+	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
+
+	builder := newMaterializedViewBuilder(materializedViewName, schemaName, databaseName)
+
+	if v, ok := d.GetOk("cluster_name"); ok {
+		builder.ClusterName(v.(string))
+	}
+
+	if v, ok := d.GetOk("statement"); ok {
+		builder.Statement(v.(string))
+	}
+
+	q := builder.Create()
+
+	if diags := ExecResource(ctx, conn, q); diags.HasError() {
+		return diags
+	}
+	return resourceMaterializedViewRead(ctx, d, meta)
+}
+
+func resourceMaterializedViewUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*ProviderMeta)
+	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
+
+	if d.HasChange("name") {
+		oldName, newName := d.GetChange("name")
+
+		builder := newMaterializedViewBuilder(oldName.(string), schemaName, databaseName)
+		q := builder.Rename(newName.(string))
+
+		if diags := ExecResource(ctx, conn, q); diags.HasError() {
+			return diags
+		}
+	}
+
+	return resourceMaterializedViewRead(ctx, d, meta)
+}
+
+func resourceMaterializedViewDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*ProviderMeta)
+	materializedViewName := d.Get("name").(string)
+	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
+
+	builder := newMaterializedViewBuilder(materializedViewName, schemaName, databaseName)
+	q := builder.Drop()
+
+	return ExecResource(ctx, conn, q)
+}
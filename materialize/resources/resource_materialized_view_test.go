@@ -0,0 +1,55 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceMaterializedViewCreate(t *testing.T) {
+	r := require.New(t)
+	b := newMaterializedViewBuilder("materialized_view", "schema", "database")
+	b.Statement(`SELECT * FROM schema.table`)
+	r.Equal(`CREATE MATERIALIZED VIEW "database"."schema"."materialized_view" AS SELECT * FROM schema.table;`, b.Create())
+
+	b.ClusterName("cluster")
+	r.Equal(`CREATE MATERIALIZED VIEW "database"."schema"."materialized_view" IN CLUSTER "cluster" AS SELECT * FROM schema.table;`, b.Create())
+}
+
+func TestResourceMaterializedViewRead(t *testing.T) {
+	r := require.New(t)
+	b := newMaterializedViewBuilder("materialized_view", "schema", "database")
+	q, args := b.Read()
+	r.Equal(`
+		SELECT
+			mz_materialized_views.id,
+			mz_materialized_views.name,
+			mz_schemas.name,
+			mz_databases.name,
+			mz_clusters.name,
+			mz_materialized_views.definition
+		FROM mz_materialized_views
+		JOIN mz_schemas
+			ON mz_materialized_views.schema_id = mz_schemas.id
+		JOIN mz_databases
+			ON mz_schemas.database_id = mz_databases.id
+		JOIN mz_clusters
+			ON mz_materialized_views.cluster_id = mz_clusters.id
+		WHERE mz_materialized_views.name = $1
+		AND mz_schemas.name = $2
+		AND mz_databases.name = $3;
+	`, q)
+	r.Equal([]interface{}{"materialized_view", "schema", "database"}, args)
+}
+
+func TestResourceMaterializedViewRename(t *testing.T) {
+	r := require.New(t)
+	b := newMaterializedViewBuilder("materialized_view", "schema", "database")
+	r.Equal(`ALTER MATERIALIZED VIEW "database"."schema"."materialized_view" RENAME TO "new_view";`, b.Rename("new_view"))
+}
+
+func TestResourceMaterializedViewDrop(t *testing.T) {
+	r := require.New(t)
+	b := newMaterializedViewBuilder("materialized_view", "schema", "database")
+	r.Equal(`DROP MATERIALIZED VIEW "database"."schema"."materialized_view";`, b.Drop())
+}
@@ -0,0 +1,259 @@
+package resources
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func Role() *schema.Resource {
+	return importDefaults(&schema.Resource{
+		Description: "A role is a collection of privileges you can apply to users.",
+
+		CreateContext: resourceRoleCreate,
+		ReadContext:   resourceRoleRead,
+		UpdateContext: resourceRoleUpdate,
+		DeleteContext: resourceRoleDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importQualifiedName(resourceRoleRead, "name"),
+		},
+
+		Timeouts: defaultTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "The name of the role.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"inherit": {
+				Description: "Grants the role the ability to inherit privileges of other roles.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"create_role": {
+				Description: "Allows creating, altering, deleting roles and the ability to grant and revoke role membership.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"create_db": {
+				Description: "Allows creating databases.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"create_cluster": {
+				Description: "Allows creating clusters.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+		},
+	})
+}
+
+type RoleBuilder struct {
+	roleName      string
+	inherit       bool
+	noInherit     bool
+	createRole    bool
+	createDb      bool
+	createCluster bool
+}
+
+func newRoleBuilder(roleName string) *RoleBuilder {
+	return &RoleBuilder{
+		roleName: roleName,
+	}
+}
+
+func (b *RoleBuilder) Inherit() *RoleBuilder {
+	b.inherit = true
+	return b
+}
+
+func (b *RoleBuilder) NoInherit() *RoleBuilder {
+	b.noInherit = true
+	return b
+}
+
+func (b *RoleBuilder) CreateRole() *RoleBuilder {
+	b.createRole = true
+	return b
+}
+
+func (b *RoleBuilder) CreateDb() *RoleBuilder {
+	b.createDb = true
+	return b
+}
+
+func (b *RoleBuilder) CreateCluster() *RoleBuilder {
+	b.createCluster = true
+	return b
+}
+
+func (b *RoleBuilder) Create() string {
+	q := strings.Builder{}
+	q.WriteString(fmt.Sprintf(`CREATE ROLE %s`, quoteIdentifier(b.roleName)))
+
+	var p []string
+	if b.inherit {
+		p = append(p, `INHERIT`)
+	}
+
+	if b.noInherit {
+		p = append(p, `NOINHERIT`)
+	}
+
+	if b.createRole {
+		p = append(p, `CREATEROLE`)
+	}
+
+	if b.createDb {
+		p = append(p, `CREATEDB`)
+	}
+
+	if b.createCluster {
+		p = append(p, `CREATECLUSTER`)
+	}
+
+	if len(p) != 0 {
+		q.WriteString(fmt.Sprintf(` WITH %s`, strings.Join(p[:], " ")))
+	}
+
+	q.WriteString(`;`)
+	return q.String()
+}
+
+func (b *RoleBuilder) Read() (string, []interface{}) {
+	return `
+		SELECT
+			id,
+			name,
+			inherit,
+			create_role,
+			create_db,
+			create_cluster
+		FROM mz_roles
+		WHERE name = $1;
+	`, []interface{}{b.roleName}
+}
+
+// Alter sets a single role attribute, e.g. CREATEDB or NOCREATEDB
+func (b *RoleBuilder) Alter(attribute string) string {
+	return fmt.Sprintf(`ALTER ROLE %s WITH %s;`, quoteIdentifier(b.roleName), attribute)
+}
+
+func (b *RoleBuilder) Drop() string {
+	return fmt.Sprintf(`DROP ROLE %s;`, quoteIdentifier(b.roleName))
+}
+
+func resourceRoleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	conn := meta.(*ProviderMeta)
+	roleName := d.Get("name").(string)
+
+	builder := newRoleBuilder(roleName)
+	q, args := builder.Read()
+
+	var id, name string
+	var inherit, createRole, createDb, createCluster bool
+	if err := queryRow(ctx, conn, q, args, &id, &name, &inherit, &createRole, &createDb, &createCluster); err == sql.ErrNoRows {
+		log.Printf("[WARN] role (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return diags
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id)
+	d.Set("name", name)
+	d.Set("inherit", inherit)
+	d.Set("create_role", createRole)
+	d.Set("create_db", createDb)
+	d.Set("create_cluster", createCluster)
+
+	return diags
+}
+
+func resourceRoleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*ProviderMeta)
+	roleName := d.Get("name").(string)
+
+	builder := newRoleBuilder(roleName)
+
+	// GetOk reports false as unset, so NOINHERIT is only emitted through Get
+	if d.Get("inherit").(bool) {
+		builder.Inherit()
+	} else {
+		builder.NoInherit()
+	}
+
+	if v, ok := d.GetOk("create_role"); ok && v.(bool) {
+		builder.CreateRole()
+	}
+
+	if v, ok := d.GetOk("create_db"); ok && v.(bool) {
+		builder.CreateDb()
+	}
+
+	if v, ok := d.GetOk("create_cluster"); ok && v.(bool) {
+		builder.CreateCluster()
+	}
+
+	q := builder.Create()
+
+	if diags := ExecResource(ctx, conn, q); diags.HasError() {
+		return diags
+	}
+	return resourceRoleRead(ctx, d, meta)
+}
+
+func resourceRoleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*ProviderMeta)
+	roleName := d.Get("name").(string)
+
+	builder := newRoleBuilder(roleName)
+
+	attributes := map[string]string{
+		"inherit":        "INHERIT",
+		"create_role":    "CREATEROLE",
+		"create_db":      "CREATEDB",
+		"create_cluster": "CREATECLUSTER",
+	}
+
+	for k, a := range attributes {
+		if d.HasChange(k) {
+			if !d.Get(k).(bool) {
+				a = "NO" + a
+			}
+
+			q := builder.Alter(a)
+			if diags := ExecResource(ctx, conn, q); diags.HasError() {
+				return diags
+			}
+		}
+	}
+
+	return resourceRoleRead(ctx, d, meta)
+}
+
+func resourceRoleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*ProviderMeta)
+	roleName := d.Get("name").(string)
+
+	builder := newRoleBuilder(roleName)
+	q := builder.Drop()
+
+	return ExecResource(ctx, conn, q)
+}
@@ -0,0 +1,150 @@
+package resources
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func RoleGrant() *schema.Resource {
+	return importDefaults(&schema.Resource{
+		Description: "Manages membership of a role in another role.",
+
+		CreateContext: resourceRoleGrantCreate,
+		ReadContext:   resourceRoleGrantRead,
+		DeleteContext: resourceRoleGrantDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRoleGrantImport,
+		},
+
+		Timeouts: defaultTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			"role_name": {
+				Description: "The role being granted.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"member_name": {
+				Description: "The role that is made a member of role_name.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+		},
+	})
+}
+
+type RoleGrantBuilder struct {
+	roleName   string
+	memberName string
+}
+
+func newRoleGrantBuilder(roleName, memberName string) *RoleGrantBuilder {
+	return &RoleGrantBuilder{
+		roleName:   roleName,
+		memberName: memberName,
+	}
+}
+
+func (b *RoleGrantBuilder) Grant() string {
+	return fmt.Sprintf(`GRANT %s TO %s;`, quoteIdentifier(b.roleName), quoteIdentifier(b.memberName))
+}
+
+func (b *RoleGrantBuilder) Revoke() string {
+	return fmt.Sprintf(`REVOKE %s FROM %s;`, quoteIdentifier(b.roleName), quoteIdentifier(b.memberName))
+}
+
+func (b *RoleGrantBuilder) Read() (string, []interface{}) {
+	return `
+		SELECT
+			roles.name,
+			members.name
+		FROM mz_role_members
+		JOIN mz_roles AS roles
+			ON mz_role_members.role_id = roles.id
+		JOIN mz_roles AS members
+			ON mz_role_members.member = members.id
+		WHERE roles.name = $1
+		AND members.name = $2;
+	`, []interface{}{b.roleName, b.memberName}
+}
+
+func resourceRoleGrantRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	conn := meta.(*ProviderMeta)
+	roleName := d.Get("role_name").(string)
+	memberName := d.Get("member_name").(string)
+
+	builder := newRoleGrantBuilder(roleName, memberName)
+	q, args := builder.Read()
+
+	var role, member string
+
+	// A missing row means the membership was revoked
+	if err := queryRow(ctx, conn, q, args, &role, &member); err == sql.ErrNoRows {
+		log.Printf("[WARN] role grant (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return diags
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s|%s", role, member))
+
+	return diags
+}
+
+func resourceRoleGrantCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*ProviderMeta)
+	roleName := d.Get("role_name").(string)
+	memberName := d.Get("member_name").(string)
+
+	builder := newRoleGrantBuilder(roleName, memberName)
+	q := builder.Grant()
+
+	if diags := ExecResource(ctx, conn, q); diags.HasError() {
+		return diags
+	}
+	return resourceRoleGrantRead(ctx, d, meta)
+}
+
+func resourceRoleGrantDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*ProviderMeta)
+	roleName := d.Get("role_name").(string)
+	memberName := d.Get("member_name").(string)
+
+	builder := newRoleGrantBuilder(roleName, memberName)
+	q := builder.Revoke()
+
+	return ExecResource(ctx, conn, q)
+}
+
+// Imports a role grant with an ID of the form role|member
+func resourceRoleGrantImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "|", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected role|member", d.Id())
+	}
+
+	d.Set("role_name", parts[0])
+	d.Set("member_name", parts[1])
+
+	if diags := resourceRoleGrantRead(ctx, d, meta); diags.HasError() {
+		return nil, fmt.Errorf("unable to read role grant %s: %s", d.Id(), diagnosticText(diags[0]))
+	}
+
+	if d.Id() == "" {
+		return nil, fmt.Errorf("role %s is not a member of role %s", parts[1], parts[0])
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
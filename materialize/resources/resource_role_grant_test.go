@@ -0,0 +1,56 @@
+package resources
+
+import (
+	"database/sql"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceRoleGrantGrant(t *testing.T) {
+	r := require.New(t)
+	b := newRoleGrantBuilder("role", "member")
+	r.Equal(`GRANT "role" TO "member";`, b.Grant())
+}
+
+func TestResourceRoleGrantRevoke(t *testing.T) {
+	r := require.New(t)
+	b := newRoleGrantBuilder("role", "member")
+	r.Equal(`REVOKE "role" FROM "member";`, b.Revoke())
+}
+
+func TestResourceRoleGrantRead(t *testing.T) {
+	r := require.New(t)
+	b := newRoleGrantBuilder("role", "member")
+	q, args := b.Read()
+	r.Equal(`
+		SELECT
+			roles.name,
+			members.name
+		FROM mz_role_members
+		JOIN mz_roles AS roles
+			ON mz_role_members.role_id = roles.id
+		JOIN mz_roles AS members
+			ON mz_role_members.member = members.id
+		WHERE roles.name = $1
+		AND members.name = $2;
+	`, q)
+	r.Equal([]interface{}{"role", "member"}, args)
+}
+
+func TestResourceRoleGrantImport(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(`SELECT\s+roles.name`).WithArgs("team:admins", "member").WillReturnRows(
+			sqlmock.NewRows([]string{"role", "member"}).AddRow("team:admins", "member"),
+		)
+
+		diff := ImportPlan(t, RoleGrant(), "team:admins|member", map[string]interface{}{
+			"role_name":   "team:admins",
+			"member_name": "member",
+		}, testMeta(db))
+		r.True(diff.Empty(), "%v", diff)
+	})
+}
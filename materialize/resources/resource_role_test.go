@@ -0,0 +1,58 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceRoleCreate(t *testing.T) {
+	r := require.New(t)
+	b := newRoleBuilder("role")
+	r.Equal(`CREATE ROLE "role";`, b.Create())
+
+	b.Inherit()
+	b.CreateRole()
+	b.CreateDb()
+	b.CreateCluster()
+	r.Equal(`CREATE ROLE "role" WITH INHERIT CREATEROLE CREATEDB CREATECLUSTER;`, b.Create())
+}
+
+func TestResourceRoleCreateNoInherit(t *testing.T) {
+	r := require.New(t)
+	b := newRoleBuilder("role")
+	b.NoInherit()
+	b.CreateDb()
+	r.Equal(`CREATE ROLE "role" WITH NOINHERIT CREATEDB;`, b.Create())
+}
+
+func TestResourceRoleRead(t *testing.T) {
+	r := require.New(t)
+	b := newRoleBuilder("role")
+	q, args := b.Read()
+	r.Equal(`
+		SELECT
+			id,
+			name,
+			inherit,
+			create_role,
+			create_db,
+			create_cluster
+		FROM mz_roles
+		WHERE name = $1;
+	`, q)
+	r.Equal([]interface{}{"role"}, args)
+}
+
+func TestResourceRoleAlter(t *testing.T) {
+	r := require.New(t)
+	b := newRoleBuilder("role")
+	r.Equal(`ALTER ROLE "role" WITH CREATEDB;`, b.Alter("CREATEDB"))
+	r.Equal(`ALTER ROLE "role" WITH NOCREATECLUSTER;`, b.Alter("NOCREATECLUSTER"))
+}
+
+func TestResourceRoleDrop(t *testing.T) {
+	r := require.New(t)
+	b := newRoleBuilder("role")
+	r.Equal(`DROP ROLE "role";`, b.Drop())
+}
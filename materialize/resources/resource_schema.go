@@ -4,19 +4,29 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func Schema() *schema.Resource {
-	return &schema.Resource{
+	return importDefaults(&schema.Resource{
 		Description: "The highest level namespace hierarchy in Materialize.",
 
 		CreateContext: resourceSchemaCreate,
 		ReadContext:   resourceSchemaRead,
+		UpdateContext: resourceSchemaUpdate,
 		DeleteContext: resourceSchemaDelete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: importQualifiedName(resourceSchemaRead, "database_name", "name"),
+		},
+
+		Timeouts: defaultTimeouts(),
+
+		CustomizeDiff: defaultDatabaseName,
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Description: "The name of the schema.",
@@ -25,14 +35,25 @@ func Schema() *schema.Resource {
 				ForceNew:    true,
 			},
 			"database_name": {
-				Description: "The name of the database.",
+				Description: "The name of the database. Defaults to the database of the provider configuration.",
 				Type:        schema.TypeString,
 				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Default:     "materialize",
+			},
+			"ownership_role": {
+				Description: "The owner of the object.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"comment": {
+				Description: "A comment describing the object.",
+				Type:        schema.TypeString,
+				Optional:    true,
 			},
 		},
-	}
+	})
 }
 
 type SchemaBuilder struct {
@@ -48,63 +69,133 @@ func newSchemaBuilder(schemaName, databaseName string) *SchemaBuilder {
 }
 
 func (b *SchemaBuilder) Create() string {
-	return fmt.Sprintf(`CREATE SCHEMA %s.%s;`, b.databaseName, b.schemaName)
+	return fmt.Sprintf(`CREATE SCHEMA %s;`, qualifiedName(b.databaseName, b.schemaName))
 }
 
-func (b *SchemaBuilder) Read() string {
-	return fmt.Sprintf(`
-		SELECT mz_schemas.id, mz_schemas.name, mz_databases.name
+func (b *SchemaBuilder) Read() (string, []interface{}) {
+	return `
+		SELECT mz_schemas.id, mz_schemas.name, mz_databases.name, mz_roles.name, mz_comments.comment
 		FROM mz_schemas JOIN mz_databases
 			ON mz_schemas.database_id = mz_databases.id
-		WHERE mz_schemas.name = '%s'
-		AND mz_databases.name = '%s';	
-	`, b.schemaName, b.databaseName)
+		JOIN mz_roles
+			ON mz_schemas.owner_id = mz_roles.id
+		LEFT JOIN mz_internal.mz_comments
+			ON mz_schemas.id = mz_comments.id
+			AND mz_comments.object_type = 'schema'
+			AND mz_comments.object_sub_id IS NULL
+		WHERE mz_schemas.name = $1
+		AND mz_databases.name = $2;
+	`, []interface{}{b.schemaName, b.databaseName}
+}
+
+func (b *SchemaBuilder) AlterOwner(roleName string) string {
+	return fmt.Sprintf(`ALTER SCHEMA %s OWNER TO %s;`, qualifiedName(b.databaseName, b.schemaName), quoteIdentifier(roleName))
+}
+
+func (b *SchemaBuilder) Comment(comment string) string {
+	if comment == "" {
+		return fmt.Sprintf(`COMMENT ON SCHEMA %s IS NULL;`, qualifiedName(b.databaseName, b.schemaName))
+	}
+	return fmt.Sprintf(`COMMENT ON SCHEMA %s IS %s;`, qualifiedName(b.databaseName, b.schemaName), quoteString(comment))
 }
 
 func (b *SchemaBuilder) Drop() string {
-	return fmt.Sprintf(`DROP SCHEMA %s.%s;`, b.databaseName, b.schemaName)
+	return fmt.Sprintf(`DROP SCHEMA %s;`, qualifiedName(b.databaseName, b.schemaName))
 }
 
 func resourceSchemaRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	conn := meta.(*sql.DB)
+	conn := meta.(*ProviderMeta)
 	schemaName := d.Get("name").(string)
 	databaseName := d.Get("database_name").(string)
 
 	builder := newSchemaBuilder(schemaName, databaseName)
-	q := builder.Read()
-
-	var id, name, database string
-	conn.QueryRow(q).Scan(&id, &name, &database)
+	q, args := builder.Read()
+
+	var id, name, database, owner string
+	var comment sql.NullString
+	if err := queryRow(ctx, conn, q, args, &id, &name, &database, &owner, &comment); err == sql.ErrNoRows {
+		log.Printf("[WARN] schema (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return diags
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
 
 	d.SetId(id)
+	d.Set("name", name)
+	d.Set("database_name", database)
+	d.Set("ownership_role", owner)
+	d.Set("comment", comment.String)
 
 	return diags
 }
 
 func resourceSchemaCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	conn := meta.(*sql.DB)
+	conn := meta.(*ProviderMeta)
 	schemaName := d.Get("name").(string)
 	databaseName := d.Get("database_name").(string)
 
 	builder := newSchemaBuilder(schemaName, databaseName)
 	q := builder.Create()
 
-	ExecResource(conn, q)
+	if diags := ExecResource(ctx, conn, q); diags.HasError() {
+		return diags
+	}
+
+	if v, ok := d.GetOk("ownership_role"); ok {
+		if diags := ExecResource(ctx, conn, builder.AlterOwner(v.(string))); diags.HasError() {
+			return diags
+		}
+	}
+
+	if v, ok := d.GetOk("comment"); ok {
+		if diags := ExecResource(ctx, conn, builder.Comment(v.(string))); diags.HasError() {
+			return diags
+		}
+	}
+
 	return resourceSchemaRead(ctx, d, meta)
 }
 
-func resourceSchemaDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
+func resourceSchemaUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*ProviderMeta)
+	schemaName := d.Get("name").(string)
+	databaseName := d.Get("database_name").(string)
+
+	if d.HasChange("ownership_role") {
+		_, newRole := d.GetChange("ownership_role")
+
+		builder := newSchemaBuilder(schemaName, databaseName)
+		q := builder.AlterOwner(newRole.(string))
+
+		if diags := ExecResource(ctx, conn, q); diags.HasError() {
+			return diags
+		}
+	}
+
+	if d.HasChange("comment") {
+		_, newComment := d.GetChange("comment")
+
+		builder := newSchemaBuilder(schemaName, databaseName)
+		q := builder.Comment(newComment.(string))
+
+		if diags := ExecResource(ctx, conn, q); diags.HasError() {
+			return diags
+		}
+	}
 
-	conn := meta.(*sql.DB)
+	return resourceSchemaRead(ctx, d, meta)
+}
+
+func resourceSchemaDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*ProviderMeta)
 	schemaName := d.Get("name").(string)
 	databaseName := d.Get("database_name").(string)
 
 	builder := newSchemaBuilder(schemaName, databaseName)
 	q := builder.Drop()
 
-	ExecResource(conn, q)
-	return diags
+	return ExecResource(ctx, conn, q)
 }
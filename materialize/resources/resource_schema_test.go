@@ -9,23 +9,49 @@ import (
 func TestResourceSchemaRead(t *testing.T) {
 	r := require.New(t)
 	b := newSchemaBuilder("schema", "database")
+	q, args := b.Read()
 	r.Equal(`
-		SELECT mz_schemas.id, mz_schemas.name, mz_databases.name
+		SELECT mz_schemas.id, mz_schemas.name, mz_databases.name, mz_roles.name, mz_comments.comment
 		FROM mz_schemas JOIN mz_databases
 			ON mz_schemas.database_id = mz_databases.id
-		WHERE mz_schemas.name = 'schema'
-		AND mz_databases.name = 'database';	
-	`, b.Read())
+		JOIN mz_roles
+			ON mz_schemas.owner_id = mz_roles.id
+		LEFT JOIN mz_internal.mz_comments
+			ON mz_schemas.id = mz_comments.id
+			AND mz_comments.object_type = 'schema'
+			AND mz_comments.object_sub_id IS NULL
+		WHERE mz_schemas.name = $1
+		AND mz_databases.name = $2;
+	`, q)
+	r.Equal([]interface{}{"schema", "database"}, args)
 }
 
 func TestResourceSchemaCreate(t *testing.T) {
 	r := require.New(t)
 	b := newSchemaBuilder("schema", "database")
-	r.Equal(`CREATE SCHEMA database.schema;`, b.Create())
+	r.Equal(`CREATE SCHEMA "database"."schema";`, b.Create())
+}
+
+func TestResourceSchemaAlterOwner(t *testing.T) {
+	r := require.New(t)
+	b := newSchemaBuilder("schema", "database")
+	r.Equal(`ALTER SCHEMA "database"."schema" OWNER TO "role";`, b.AlterOwner("role"))
+}
+
+func TestResourceSchemaComment(t *testing.T) {
+	r := require.New(t)
+	b := newSchemaBuilder("schema", "database")
+	r.Equal(`COMMENT ON SCHEMA "database"."schema" IS 'A comment';`, b.Comment("A comment"))
+}
+
+func TestResourceSchemaCommentRemove(t *testing.T) {
+	r := require.New(t)
+	b := newSchemaBuilder("schema", "database")
+	r.Equal(`COMMENT ON SCHEMA "database"."schema" IS NULL;`, b.Comment(""))
 }
 
 func TestResourceSchemaDrop(t *testing.T) {
 	r := require.New(t)
 	b := newSchemaBuilder("schema", "database")
-	r.Equal(`DROP SCHEMA database.schema;`, b.Drop())
+	r.Equal(`DROP SCHEMA "database"."schema";`, b.Drop())
 }
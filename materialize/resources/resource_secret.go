@@ -4,13 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func Secret() *schema.Resource {
-	return &schema.Resource{
+	return importDefaults(&schema.Resource{
 		Description: "A secret securely stores sensitive credentials (like passwords and SSL keys) in Materialize’s secret management system.",
 
 		CreateContext: resourceSecretCreate,
@@ -18,6 +19,14 @@ func Secret() *schema.Resource {
 		UpdateContext: resourceSecretUpdate,
 		DeleteContext: resourceSecretDelete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: importQualifiedName(resourceSecretRead, "database_name", "schema_name", "name"),
+		},
+
+		Timeouts: defaultTimeouts(),
+
+		CustomizeDiff: defaultDatabaseName,
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Description: "The identifier for the secret.",
@@ -28,122 +37,228 @@ func Secret() *schema.Resource {
 				Description: "The identifier for the secret schema.",
 				Type:        schema.TypeString,
 				Optional:    true,
+				ForceNew:    true,
 				Default:     "public",
 			},
+			"database_name": {
+				Description: "The identifier for the secret database. Defaults to the database of the provider configuration.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
 			"value": {
 				Description: "The value for the secret. The value expression may not reference any relations, and must be implicitly castable to bytea.",
 				Type:        schema.TypeString,
 				Required:    true,
 				Sensitive:   true,
 			},
+			"ownership_role": {
+				Description: "The owner of the object.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"comment": {
+				Description: "A comment describing the object.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
 		},
-	}
+	})
 }
 
 type SecretBuilder struct {
-	secretName string
-	schemaName string
+	secretName   string
+	schemaName   string
+	databaseName string
 }
 
-func newSecretBuilder(secretName, schemaName string) *SecretBuilder {
+func newSecretBuilder(secretName, schemaName, databaseName string) *SecretBuilder {
 	return &SecretBuilder{
-		secretName: secretName,
-		schemaName: schemaName,
+		secretName:   secretName,
+		schemaName:   schemaName,
+		databaseName: databaseName,
 	}
 }
 
-func (b *SecretBuilder) Create(value string) string {
-	return fmt.Sprintf(`CREATE SECRET %s.%s AS %s;`, b.schemaName, b.secretName, value)
+// Secret values must not be logged, so the statements setting a value are
+// returned together with a copy that has the value redacted
+const redactedValue = "********"
+
+func (b *SecretBuilder) Create(value string) (string, string) {
+	q := `CREATE SECRET %s AS %s;`
+	name := qualifiedName(b.databaseName, b.schemaName, b.secretName)
+	return fmt.Sprintf(q, name, value), fmt.Sprintf(q, name, redactedValue)
 }
 
-func (b *SecretBuilder) Read() string {
-	return fmt.Sprintf(`
-		SELECT mz_secrets.id, mz_secrets.name, mz_schemas.name
+func (b *SecretBuilder) Read() (string, []interface{}) {
+	return `
+		SELECT mz_secrets.id, mz_secrets.name, mz_schemas.name, mz_databases.name, mz_roles.name, mz_comments.comment
 		FROM mz_secrets JOIN mz_schemas
 			ON mz_secrets.schema_id = mz_schemas.id
-		WHERE mz_secrets.name = '%s'
-		AND mz_schemas.name = '%s';
-	`, b.secretName, b.schemaName)
+		JOIN mz_databases
+			ON mz_schemas.database_id = mz_databases.id
+		JOIN mz_roles
+			ON mz_secrets.owner_id = mz_roles.id
+		LEFT JOIN mz_internal.mz_comments
+			ON mz_secrets.id = mz_comments.id
+			AND mz_comments.object_type = 'secret'
+			AND mz_comments.object_sub_id IS NULL
+		WHERE mz_secrets.name = $1
+		AND mz_schemas.name = $2
+		AND mz_databases.name = $3;
+	`, []interface{}{b.secretName, b.schemaName, b.databaseName}
 }
 
 func (b *SecretBuilder) Rename(newName string) string {
-	return fmt.Sprintf(`ALTER SECRET %s.%s RENAME TO %s.%s;`, b.schemaName, b.secretName, b.schemaName, newName)
+	return fmt.Sprintf(`ALTER SECRET %s RENAME TO %s;`, qualifiedName(b.databaseName, b.schemaName, b.secretName), quoteIdentifier(newName))
+}
+
+func (b *SecretBuilder) UpdateValue(newValue string) (string, string) {
+	q := `ALTER SECRET %s AS %s;`
+	name := qualifiedName(b.databaseName, b.schemaName, b.secretName)
+	return fmt.Sprintf(q, name, newValue), fmt.Sprintf(q, name, redactedValue)
 }
 
-func (b *SecretBuilder) UpdateValue(newValue string) string {
-	return fmt.Sprintf(`ALTER SECRET %s.%s AS %s;`, b.schemaName, b.secretName, newValue)
+func (b *SecretBuilder) AlterOwner(roleName string) string {
+	return fmt.Sprintf(`ALTER SECRET %s OWNER TO %s;`, qualifiedName(b.databaseName, b.schemaName, b.secretName), quoteIdentifier(roleName))
+}
+
+func (b *SecretBuilder) Comment(comment string) string {
+	if comment == "" {
+		return fmt.Sprintf(`COMMENT ON SECRET %s IS NULL;`, qualifiedName(b.databaseName, b.schemaName, b.secretName))
+	}
+	return fmt.Sprintf(`COMMENT ON SECRET %s IS %s;`, qualifiedName(b.databaseName, b.schemaName, b.secretName), quoteString(comment))
 }
 
 func (b *SecretBuilder) Drop() string {
-	return fmt.Sprintf(`DROP SECRET %s.%s;`, b.schemaName, b.secretName)
+	return fmt.Sprintf(`DROP SECRET %s;`, qualifiedName(b.databaseName, b.schemaName, b.secretName))
 }
 
 func resourceSecretRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	conn := meta.(*sql.DB)
+	conn := meta.(*ProviderMeta)
 	secretName := d.Get("name").(string)
 	schemaName := d.Get("schema_name").(string)
-
-	builder := newSecretBuilder(secretName, schemaName)
-	q := builder.Read()
-
-	var id, name, schema string
-	conn.QueryRow(q).Scan(&id, &name, &schema)
+	databaseName := d.Get("database_name").(string)
+
+	builder := newSecretBuilder(secretName, schemaName, databaseName)
+	q, args := builder.Read()
+
+	var id, name, schema, database, owner string
+	var comment sql.NullString
+	if err := queryRow(ctx, conn, q, args, &id, &name, &schema, &database, &owner, &comment); err == sql.ErrNoRows {
+		log.Printf("[WARN] secret (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return diags
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
 
 	d.SetId(id)
+	d.Set("name", name)
+	d.Set("schema_name", schema)
+	d.Set("database_name", database)
+	d.Set("ownership_role", owner)
+	d.Set("comment", comment.String)
 
 	return diags
 }
 
 func resourceSecretCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	conn := meta.(*sql.DB)
+	conn := meta.(*ProviderMeta)
 	secretName := d.Get("name").(string)
 	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
 	value := d.Get("value").(string)
 
-	builder := newSecretBuilder(secretName, schemaName)
-	q := builder.Create(value)
+	builder := newSecretBuilder(secretName, schemaName, databaseName)
+	q, redacted := builder.Create(value)
+
+	if diags := execRedactedResource(ctx, conn, q, redacted); diags.HasError() {
+		return diags
+	}
+
+	if v, ok := d.GetOk("ownership_role"); ok {
+		if diags := ExecResource(ctx, conn, builder.AlterOwner(v.(string))); diags.HasError() {
+			return diags
+		}
+	}
+
+	if v, ok := d.GetOk("comment"); ok {
+		if diags := ExecResource(ctx, conn, builder.Comment(v.(string))); diags.HasError() {
+			return diags
+		}
+	}
 
-	ExecResource(conn, q)
 	return resourceSecretRead(ctx, d, meta)
 }
 
 func resourceSecretUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	conn := meta.(*sql.DB)
-	schemaName := d.Get("name").(string)
+	conn := meta.(*ProviderMeta)
+	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
 
 	if d.HasChange("name") {
 		oldName, newName := d.GetChange("name")
 
-		builder := newSecretBuilder(oldName.(string), schemaName)
+		builder := newSecretBuilder(oldName.(string), schemaName, databaseName)
 		q := builder.Rename(newName.(string))
 
-		ExecResource(conn, q)
+		if diags := ExecResource(ctx, conn, q); diags.HasError() {
+			return diags
+		}
 	}
 
 	if d.HasChange("value") {
-		oldValue, newValue := d.GetChange("value")
+		secretName := d.Get("name").(string)
+		_, newValue := d.GetChange("value")
+
+		builder := newSecretBuilder(secretName, schemaName, databaseName)
+		q, redacted := builder.UpdateValue(newValue.(string))
 
-		builder := newSecretBuilder(oldValue.(string), schemaName)
-		q := builder.UpdateValue(newValue.(string))
+		if diags := execRedactedResource(ctx, conn, q, redacted); diags.HasError() {
+			return diags
+		}
+	}
+
+	if d.HasChange("ownership_role") {
+		secretName := d.Get("name").(string)
+		_, newRole := d.GetChange("ownership_role")
+
+		builder := newSecretBuilder(secretName, schemaName, databaseName)
+		q := builder.AlterOwner(newRole.(string))
+
+		if diags := ExecResource(ctx, conn, q); diags.HasError() {
+			return diags
+		}
+	}
 
-		ExecResource(conn, q)
+	if d.HasChange("comment") {
+		secretName := d.Get("name").(string)
+		_, newComment := d.GetChange("comment")
+
+		builder := newSecretBuilder(secretName, schemaName, databaseName)
+		q := builder.Comment(newComment.(string))
+
+		if diags := ExecResource(ctx, conn, q); diags.HasError() {
+			return diags
+		}
 	}
 
 	return resourceSecretRead(ctx, d, meta)
 }
 
 func resourceSecretDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-
-	conn := meta.(*sql.DB)
+	conn := meta.(*ProviderMeta)
 	secretName := d.Get("name").(string)
 	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
 
-	builder := newSecretBuilder(secretName, schemaName)
+	builder := newSecretBuilder(secretName, schemaName, databaseName)
 	q := builder.Drop()
 
-	ExecResource(conn, q)
-	return diags
+	return ExecResource(ctx, conn, q)
 }
@@ -1,43 +1,144 @@
 package resources
 
 import (
+	"context"
+	"database/sql"
 	"testing"
 
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 )
 
 func TestResourceSecretRead(t *testing.T) {
 	r := require.New(t)
-	b := newSecretBuilder("secret", "schema")
+	b := newSecretBuilder("secret", "schema", "database")
+	q, args := b.Read()
 	r.Equal(`
-		SELECT mz_secrets.id, mz_secrets.name, mz_schemas.name
+		SELECT mz_secrets.id, mz_secrets.name, mz_schemas.name, mz_databases.name, mz_roles.name, mz_comments.comment
 		FROM mz_secrets JOIN mz_schemas
 			ON mz_secrets.schema_id = mz_schemas.id
-		WHERE mz_secrets.name = 'secret'
-		AND mz_schemas.name = 'schema';
-	`, b.Read())
+		JOIN mz_databases
+			ON mz_schemas.database_id = mz_databases.id
+		JOIN mz_roles
+			ON mz_secrets.owner_id = mz_roles.id
+		LEFT JOIN mz_internal.mz_comments
+			ON mz_secrets.id = mz_comments.id
+			AND mz_comments.object_type = 'secret'
+			AND mz_comments.object_sub_id IS NULL
+		WHERE mz_secrets.name = $1
+		AND mz_schemas.name = $2
+		AND mz_databases.name = $3;
+	`, q)
+	r.Equal([]interface{}{"secret", "schema", "database"}, args)
 }
 
 func TestResourceSecretCreate(t *testing.T) {
 	r := require.New(t)
-	b := newSecretBuilder("secret", "schema")
-	r.Equal(`CREATE SECRET schema.secret AS decode('c2VjcmV0Cg==', 'base64');`, b.Create(`decode('c2VjcmV0Cg==', 'base64')`))
+	b := newSecretBuilder("secret", "schema", "database")
+	q, redacted := b.Create(`decode('c2VjcmV0Cg==', 'base64')`)
+	r.Equal(`CREATE SECRET "database"."schema"."secret" AS decode('c2VjcmV0Cg==', 'base64');`, q)
+	r.Equal(`CREATE SECRET "database"."schema"."secret" AS ********;`, redacted)
 }
 
 func TestResourceSecretRename(t *testing.T) {
 	r := require.New(t)
-	b := newSecretBuilder("secret", "schema")
-	r.Equal(`ALTER SECRET schema.secret RENAME TO schema.new_secret;`, b.Rename("new_secret"))
+	b := newSecretBuilder("secret", "schema", "database")
+	r.Equal(`ALTER SECRET "database"."schema"."secret" RENAME TO "new_secret";`, b.Rename("new_secret"))
 }
 
 func TestResourceSecretUpdateValue(t *testing.T) {
 	r := require.New(t)
-	b := newSecretBuilder("secret", "schema")
-	r.Equal(`ALTER SECRET schema.secret AS decode('c2VjcmV0Cgdd', 'base64');`, b.UpdateValue(`decode('c2VjcmV0Cgdd', 'base64')`))
+	b := newSecretBuilder("secret", "schema", "database")
+	q, redacted := b.UpdateValue(`decode('c2VjcmV0Cgdd', 'base64')`)
+	r.Equal(`ALTER SECRET "database"."schema"."secret" AS decode('c2VjcmV0Cgdd', 'base64');`, q)
+	r.Equal(`ALTER SECRET "database"."schema"."secret" AS ********;`, redacted)
+}
+
+func TestResourceSecretAlterOwner(t *testing.T) {
+	r := require.New(t)
+	b := newSecretBuilder("secret", "schema", "database")
+	r.Equal(`ALTER SECRET "database"."schema"."secret" OWNER TO "role";`, b.AlterOwner("role"))
+}
+
+func TestResourceSecretComment(t *testing.T) {
+	r := require.New(t)
+	b := newSecretBuilder("secret", "schema", "database")
+	r.Equal(`COMMENT ON SECRET "database"."schema"."secret" IS 'A comment';`, b.Comment("A comment"))
+}
+
+func TestResourceSecretCommentRemove(t *testing.T) {
+	r := require.New(t)
+	b := newSecretBuilder("secret", "schema", "database")
+	r.Equal(`COMMENT ON SECRET "database"."schema"."secret" IS NULL;`, b.Comment(""))
 }
 
 func TestResourceSecretDrop(t *testing.T) {
 	r := require.New(t)
-	b := newSecretBuilder("secret", "schema")
-	r.Equal(`DROP SECRET schema.secret;`, b.Drop())
+	b := newSecretBuilder("secret", "schema", "database")
+	r.Equal(`DROP SECRET "database"."schema"."secret";`, b.Drop())
+}
+
+func TestRedactStatement(t *testing.T) {
+	r := require.New(t)
+
+	b := newSecretBuilder("my secret", "my.schema", "database")
+	q, redacted := b.Create(`'password'`)
+	r.Equal(`CREATE SECRET "database"."my.schema"."my secret" AS 'password';`, q)
+	r.Equal(`CREATE SECRET "database"."my.schema"."my secret" AS ********;`, redacted)
+
+	b = newSecretBuilder("secret AS value", "schema", "data.base")
+	q, redacted = b.UpdateValue(`decode('c2VjcmV0Cg==', 'base64')`)
+	r.Equal(`ALTER SECRET "data.base"."schema"."secret AS value" AS decode('c2VjcmV0Cg==', 'base64');`, q)
+	r.Equal(`ALTER SECRET "data.base"."schema"."secret AS value" AS ********;`, redacted)
+}
+
+func TestResourceSecretCreateRedactsError(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.ExpectExec(`CREATE SECRET "database"."schema"."my secret" AS 'password';`).WillReturnError(&pq.Error{Code: "42710", Message: "catalog item 'my secret' already exists"})
+
+		d := schema.TestResourceDataRaw(t, Secret().Schema, map[string]interface{}{
+			"name":          "my secret",
+			"schema_name":   "schema",
+			"database_name": "database",
+			"value":         "'password'",
+		})
+
+		diags := resourceSecretCreate(context.TODO(), d, testMeta(db))
+		r.True(diags.HasError())
+		r.Equal("Statement: CREATE SECRET \"database\".\"schema\".\"my secret\" AS ********;\nCode: 42710", diags[0].Detail)
+		r.NotContains(diags[0].Detail, "password")
+	})
+}
+
+func TestResourceSecretUpdate(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.MatchExpectationsInOrder(true)
+		mock.ExpectExec(`ALTER SECRET "database"."schema"."secret" AS 'new_password';`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery(`SELECT mz_secrets.id`).WithArgs("secret", "schema", "database").WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name", "schema", "database", "owner", "comment"}).
+				AddRow("u1", "secret", "schema", "database", "mz_system", nil),
+		)
+
+		d := UpdateData(t, Secret(), "u1", map[string]string{
+			"id":            "u1",
+			"name":          "secret",
+			"schema_name":   "schema",
+			"database_name": "database",
+			"value":         "'old_password'",
+		}, map[string]interface{}{
+			"name":          "secret",
+			"schema_name":   "schema",
+			"database_name": "database",
+			"value":         "'new_password'",
+		}, testMeta(db))
+
+		diags := resourceSecretUpdate(context.TODO(), d, testMeta(db))
+		r.False(diags.HasError(), "%v", diags)
+	})
 }
@@ -4,7 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"log"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -12,7 +14,7 @@ import (
 )
 
 func Sink() *schema.Resource {
-	return &schema.Resource{
+	return importDefaults(&schema.Resource{
 		Description: "A connects Materialize to an external system you want to write data to, and provides details about how to encode that data.",
 
 		CreateContext: resourceSinkCreate,
@@ -20,6 +22,19 @@ func Sink() *schema.Resource {
 		UpdateContext: resourceSinkUpdate,
 		DeleteContext: resourceSinkDelete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: importQualifiedName(resourceSinkRead, "database_name", "schema_name", "name"),
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		CustomizeDiff: defaultDatabaseName,
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Description: "The identifier for the secret.",
@@ -27,38 +42,49 @@ func Sink() *schema.Resource {
 				Required:    true,
 			},
 			"schema_name": {
-				Description: "The identifier for the secret schema.",
+				Description: "The identifier for the sink schema.",
 				Type:        schema.TypeString,
 				Optional:    true,
+				ForceNew:    true,
 				Default:     "public",
 			},
+			"database_name": {
+				Description: "The identifier for the sink database. Defaults to the database of the provider configuration.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
 			"cluster_name": {
-				Description:   "The cluster to maintain this sink. If not specified, the size option must be specified.",
-				Type:          schema.TypeString,
-				Optional:      true,
-				ConflictsWith: []string{"size"},
+				Description:  "The cluster to maintain this sink. If not specified, the size option must be specified.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"cluster_name", "size"},
 			},
 			"size": {
-				Description:   "The size of the sink.",
-				Type:          schema.TypeString,
-				Optional:      true,
-				ForceNew:      true,
-				ValidateFunc:  validation.StringInSlice(sourceSizes, true),
-				ConflictsWith: []string{"cluster_name"},
+				Description:      "The size of the sink.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressCaseDiff,
+				ForceNew:         true,
+				ValidateFunc:     validation.StringInSlice(sourceSizes, true),
+				ExactlyOneOf:     []string{"cluster_name", "size"},
 			},
 			"item_name": {
-				Description: "The name of the source, table or materialized view you want to send to the sink.",
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
+				Description:      "The name of the source, table or materialized view you want to send to the sink.",
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: suppressQualifiedNameDiff,
+				ForceNew:         true,
 			},
 			// Broker
 			"kafka_connection": {
-				Description:  "The name of the Kafka connection to use in the source.",
-				Type:         schema.TypeString,
-				Optional:     true,
-				ForceNew:     true,
-				RequiredWith: []string{"kafka_connection", "topic"},
+				Description:      "The name of the Kafka connection to use in the source.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressQualifiedNameDiff,
+				ForceNew:         true,
+				RequiredWith:     []string{"kafka_connection", "topic"},
 			},
 			"topic": {
 				Description:  "The Kafka topic you want to subscribe to.",
@@ -68,31 +94,52 @@ func Sink() *schema.Resource {
 				RequiredWith: []string{"kafka_connection", "topic"},
 			},
 			"format": {
-				Description: "How to decode raw bytes from different formats into data structures it can understand at runtime",
-				Type:        schema.TypeString,
-				Optional:    true,
-				ForceNew:    true,
+				Description:      "How to decode raw bytes from different formats into data structures it can understand at runtime",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressCaseDiff,
+				ForceNew:         true,
 			},
 			"envelope": {
-				Description:  "How to interpret records (e.g. Append Only, Upsert).",
-				Type:         schema.TypeString,
-				Optional:     true,
-				ForceNew:     true,
-				ValidateFunc: validation.StringInSlice(envelopes, true),
+				Description:      "How to interpret records (e.g. Append Only, Upsert).",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressCaseDiff,
+				ForceNew:         true,
+				ValidateFunc:     validation.StringInSlice(envelopes, true),
 			},
 			"schema_registry_connection": {
-				Description: "The name of the connection to use for the shcema registry.",
+				Description:      "The name of the connection to use for the shcema registry.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressQualifiedNameDiff,
+				ForceNew:         true,
+			},
+			"wait_for_ready": {
+				Description: "Wait until the sink is running before completing the create.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"ownership_role": {
+				Description: "The owner of the object.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"comment": {
+				Description: "A comment describing the object.",
 				Type:        schema.TypeString,
 				Optional:    true,
-				ForceNew:    true,
 			},
 		},
-	}
+	})
 }
 
 type SinkBuilder struct {
 	sinkName                 string
 	schemaName               string
+	databaseName             string
 	clusterName              string
 	size                     string
 	itemName                 string
@@ -103,10 +150,11 @@ type SinkBuilder struct {
 	schemaRegistryConnection string
 }
 
-func newSinkBuilder(sinkName, schemaName string) *SinkBuilder {
+func newSinkBuilder(sinkName, schemaName, databaseName string) *SinkBuilder {
 	return &SinkBuilder{
-		sinkName:   sinkName,
-		schemaName: schemaName,
+		sinkName:     sinkName,
+		schemaName:   schemaName,
+		databaseName: databaseName,
 	}
 }
 
@@ -152,15 +200,15 @@ func (b *SinkBuilder) SchemaRegistryConnection(s string) *SinkBuilder {
 
 func (b *SinkBuilder) Create() string {
 	q := strings.Builder{}
-	q.WriteString(fmt.Sprintf(`CREATE SINK %s.%s FROM %s`, b.schemaName, b.sinkName, b.itemName))
+	q.WriteString(fmt.Sprintf(`CREATE SINK %s FROM %s`, qualifiedName(b.databaseName, b.schemaName, b.sinkName), quoteReference(b.itemName)))
 
 	// Broker
 	if b.kafkaConnection != "" {
-		q.WriteString(fmt.Sprintf(` INTO KAFKA CONNECTION %s`, b.kafkaConnection))
+		q.WriteString(fmt.Sprintf(` INTO KAFKA CONNECTION %s`, quoteReference(b.kafkaConnection)))
 	}
 
 	if b.topic != "" {
-		q.WriteString(fmt.Sprintf(` (TOPIC '%s')`, b.topic))
+		q.WriteString(fmt.Sprintf(` (TOPIC %s)`, quoteString(b.topic)))
 	}
 
 	if b.format != "" {
@@ -168,7 +216,7 @@ func (b *SinkBuilder) Create() string {
 	}
 
 	if b.schemaRegistryConnection != "" {
-		q.WriteString(fmt.Sprintf(` USING CONFLUENT SCHEMA REGISTRY CONNECTION %s`, b.schemaRegistryConnection))
+		q.WriteString(fmt.Sprintf(` USING CONFLUENT SCHEMA REGISTRY CONNECTION %s`, quoteReference(b.schemaRegistryConnection)))
 	}
 
 	if b.envelope != "" {
@@ -176,58 +224,93 @@ func (b *SinkBuilder) Create() string {
 	}
 
 	if b.size != "" {
-		q.WriteString(fmt.Sprintf(` WITH (SIZE = '%s')`, b.size))
+		q.WriteString(fmt.Sprintf(` WITH (SIZE = %s)`, quoteString(b.size)))
 	} else if b.clusterName != "" {
-		q.WriteString(fmt.Sprintf(` IN CLUSTER %s`, b.clusterName))
-	} else {
-		panic(`Must include either size or cluster`)
+		q.WriteString(fmt.Sprintf(` IN CLUSTER %s`, quoteIdentifier(b.clusterName)))
 	}
 
 	q.WriteString(`;`)
 	return q.String()
 }
 
-func (b *SinkBuilder) Read() string {
-	return fmt.Sprintf(`
+func (b *SinkBuilder) ShowCreate() string {
+	return fmt.Sprintf(`SHOW CREATE SINK %s;`, qualifiedName(b.databaseName, b.schemaName, b.sinkName))
+}
+
+func (b *SinkBuilder) Read() (string, []interface{}) {
+	return `
 		SELECT
 			mz_sinks.id,
 			mz_sinks.name,
+			mz_schemas.name as schema_name,
+			mz_databases.name as database_name,
 			mz_sinks.type,
 			mz_sinks.size,
 			mz_sinks.envelope_type,
 			mz_connections.name as connection_name,
-			mz_clusters.name as cluster_name
+			mz_clusters.name as cluster_name,
+			mz_roles.name as owner_name,
+			mz_comments.comment as comment
 		FROM mz_sinks
 		JOIN mz_schemas
 			ON mz_sinks.schema_id = mz_schemas.id
+		JOIN mz_databases
+			ON mz_schemas.database_id = mz_databases.id
+		JOIN mz_roles
+			ON mz_sinks.owner_id = mz_roles.id
+		LEFT JOIN mz_internal.mz_comments
+			ON mz_sinks.id = mz_comments.id
+			AND mz_comments.object_type = 'sink'
+			AND mz_comments.object_sub_id IS NULL
 		LEFT JOIN mz_connections
 			ON mz_sinks.connection_id = mz_connections.id
 		LEFT JOIN mz_clusters
 			ON mz_sinks.cluster_id = mz_clusters.id
-		WHERE mz_sinks.name = '%s'
-		AND mz_schemas.name = '%s';
-	`, b.sinkName, b.schemaName)
+		WHERE mz_sinks.name = $1
+		AND mz_schemas.name = $2
+		AND mz_databases.name = $3;
+	`, []interface{}{b.sinkName, b.schemaName, b.databaseName}
+}
+
+func (b *SinkBuilder) ReadStatus(id string) (string, []interface{}) {
+	return `
+		SELECT mz_sink_statuses.status, mz_sink_statuses.error
+		FROM mz_internal.mz_sink_statuses
+		WHERE mz_sink_statuses.id = $1;
+	`, []interface{}{id}
 }
 
 func (b *SinkBuilder) Rename(newName string) string {
-	return fmt.Sprintf(`ALTER SINK %s.%s RENAME TO %s.%s;`, b.schemaName, b.sinkName, b.schemaName, newName)
+	return fmt.Sprintf(`ALTER SINK %s RENAME TO %s;`, qualifiedName(b.databaseName, b.schemaName, b.sinkName), quoteIdentifier(newName))
 }
 
 func (b *SinkBuilder) UpdateSize(newSize string) string {
-	return fmt.Sprintf(`ALTER SINK %s.%s SET (SIZE = '%s');`, b.schemaName, b.sinkName, newSize)
+	return fmt.Sprintf(`ALTER SINK %s SET (SIZE = %s);`, qualifiedName(b.databaseName, b.schemaName, b.sinkName), quoteString(newSize))
+}
+
+func (b *SinkBuilder) AlterOwner(roleName string) string {
+	return fmt.Sprintf(`ALTER SINK %s OWNER TO %s;`, qualifiedName(b.databaseName, b.schemaName, b.sinkName), quoteIdentifier(roleName))
+}
+
+func (b *SinkBuilder) Comment(comment string) string {
+	if comment == "" {
+		return fmt.Sprintf(`COMMENT ON SINK %s IS NULL;`, qualifiedName(b.databaseName, b.schemaName, b.sinkName))
+	}
+	return fmt.Sprintf(`COMMENT ON SINK %s IS %s;`, qualifiedName(b.databaseName, b.schemaName, b.sinkName), quoteString(comment))
 }
 
 func (b *SinkBuilder) Drop() string {
-	return fmt.Sprintf(`DROP SINK %s.%s;`, b.schemaName, b.sinkName)
+	return fmt.Sprintf(`DROP SINK %s;`, qualifiedName(b.databaseName, b.schemaName, b.sinkName))
 }
 
 func resourceSinkCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*sql.DB)
+	conn := meta.(*ProviderMeta)
 
 	sinkName := d.Get("name").(string)
 	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
 
-	builder := newSinkBuilder(sinkName, schemaName)
+	builder := newSinkBuilder(sinkName, schemaName, databaseName)
 
 	if v, ok := d.GetOk("cluster_name"); ok {
 		builder.ClusterName(v.(string))
@@ -263,64 +346,157 @@ func resourceSinkCreate(ctx context.Context, d *schema.ResourceData, meta any) d
 
 	q := builder.Create()
 
-	ExecResource(conn, q)
-	return resourceSourceRead(ctx, d, meta)
+	if diags := ExecResource(ctx, conn, q); diags.HasError() {
+		return diags
+	}
+
+	if v, ok := d.GetOk("ownership_role"); ok {
+		if diags := ExecResource(ctx, conn, builder.AlterOwner(v.(string))); diags.HasError() {
+			return diags
+		}
+	}
+
+	if v, ok := d.GetOk("comment"); ok {
+		if diags := ExecResource(ctx, conn, builder.Comment(v.(string))); diags.HasError() {
+			return diags
+		}
+	}
+
+	diags := resourceSinkRead(ctx, d, meta)
+	if diags.HasError() || d.Id() == "" {
+		return diags
+	}
+
+	if d.Get("wait_for_ready").(bool) {
+		q, args := builder.ReadStatus(d.Id())
+		if err := waitForReady(ctx, conn, d.Timeout(schema.TimeoutCreate), fmt.Sprintf("sink %s", sinkName), "running", q, args...); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return diags
 }
 
 func resourceSinkRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	conn := meta.(*sql.DB)
+	conn := meta.(*ProviderMeta)
 	sinkName := d.Get("name").(string)
 	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
+
+	builder := newSinkBuilder(sinkName, schemaName, databaseName)
+	q, args := builder.Read()
+
+	var id, name, schema_name, database_name, sink_type, owner_name string
+	var size, envelope_type, connection_name, cluster_name, comment sql.NullString
+	if err := queryRow(ctx, conn, q, args, &id, &name, &schema_name, &database_name, &sink_type, &size, &envelope_type, &connection_name, &cluster_name, &owner_name, &comment); err == sql.ErrNoRows {
+		log.Printf("[WARN] sink (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return diags
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
 
-	builder := newSinkBuilder(sinkName, schemaName)
-	q := builder.Read()
+	d.SetId(id)
+	d.Set("name", name)
+	d.Set("schema_name", schema_name)
+	d.Set("database_name", database_name)
 
-	var id, name, sink_type, size, envelope_type, connection_name, cluster_name string
-	conn.QueryRow(q).Scan(&id, &name, &sink_type, &size, &envelope_type, &connection_name, &cluster_name)
+	if size.Valid {
+		d.Set("size", size.String)
+	} else {
+		d.Set("cluster_name", cluster_name.String)
+	}
 
-	d.SetId(id)
+	// Options without a catalog column are read from the recorded statement
+	createSql, err := readCreateSql(ctx, conn, builder.ShowCreate())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("item_name", createSqlValue(createSql, "FROM"))
+	d.Set("format", createSqlFormat(createSql))
+	d.Set("schema_registry_connection", createSqlValue(createSql, "USING CONFLUENT SCHEMA REGISTRY CONNECTION"))
+
+	if sink_type == "kafka" {
+		d.Set("kafka_connection", connection_name.String)
+		d.Set("topic", createSqlValue(createSql, "TOPIC"))
+	}
+
+	if envelope_type.Valid {
+		d.Set("envelope", strings.ToUpper(envelope_type.String))
+	}
+
+	d.Set("ownership_role", owner_name)
+	d.Set("comment", comment.String)
 
 	return diags
 }
 
 func resourceSinkUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*sql.DB)
-	schemaName := d.Get("name").(string)
+	conn := meta.(*ProviderMeta)
+	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
 
 	if d.HasChange("name") {
 		oldName, newName := d.GetChange("name")
 
-		builder := newSinkBuilder(oldName.(string), schemaName)
+		builder := newSinkBuilder(oldName.(string), schemaName, databaseName)
 		q := builder.Rename(newName.(string))
 
-		ExecResource(conn, q)
+		if diags := ExecResource(ctx, conn, q); diags.HasError() {
+			return diags
+		}
 	}
 
 	if d.HasChange("size") {
-		sourceName := d.Get("sourceName").(string)
+		sourceName := d.Get("name").(string)
 		_, newSize := d.GetChange("size")
 
-		builder := newSinkBuilder(sourceName, schemaName)
+		builder := newSinkBuilder(sourceName, schemaName, databaseName)
 		q := builder.UpdateSize(newSize.(string))
 
-		ExecResource(conn, q)
+		if diags := ExecResource(ctx, conn, q); diags.HasError() {
+			return diags
+		}
 	}
 
-	return resourceSecretRead(ctx, d, meta)
+	if d.HasChange("ownership_role") {
+		sinkName := d.Get("name").(string)
+		_, newRole := d.GetChange("ownership_role")
+
+		builder := newSinkBuilder(sinkName, schemaName, databaseName)
+		q := builder.AlterOwner(newRole.(string))
+
+		if diags := ExecResource(ctx, conn, q); diags.HasError() {
+			return diags
+		}
+	}
+
+	if d.HasChange("comment") {
+		sinkName := d.Get("name").(string)
+		_, newComment := d.GetChange("comment")
+
+		builder := newSinkBuilder(sinkName, schemaName, databaseName)
+		q := builder.Comment(newComment.(string))
+
+		if diags := ExecResource(ctx, conn, q); diags.HasError() {
+			return diags
+		}
+	}
+
+	return resourceSinkRead(ctx, d, meta)
 }
 
 func resourceSinkDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	var diags diag.Diagnostics
-
-	conn := meta.(*sql.DB)
+	conn := meta.(*ProviderMeta)
 	sinkName := d.Get("name").(string)
 	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
 
-	builder := newSinkBuilder(sinkName, schemaName)
+	builder := newSinkBuilder(sinkName, schemaName, databaseName)
 	q := builder.Drop()
 
-	ExecResource(conn, q)
-	return diags
+	return ExecResource(ctx, conn, q)
 }
@@ -1,28 +1,31 @@
 package resources
 
 import (
+	"database/sql"
 	"testing"
 
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/require"
 )
 
 func TestResourceSinkCreate(t *testing.T) {
 	r := require.New(t)
 
-	bs := newSinkBuilder("sink", "schema")
+	bs := newSinkBuilder("sink", "schema", "database")
 	bs.Size("xsmall")
 	bs.ItemName("schema.table")
-	r.Equal(`CREATE SINK schema.sink FROM schema.table WITH (SIZE = 'xsmall');`, bs.Create())
+	r.Equal(`CREATE SINK "database"."schema"."sink" FROM "schema"."table" WITH (SIZE = 'xsmall');`, bs.Create())
 
-	bc := newSinkBuilder("sink", "schema")
+	bc := newSinkBuilder("sink", "schema", "database")
 	bc.ClusterName("cluster")
 	bc.ItemName("schema.table")
-	r.Equal(`CREATE SINK schema.sink FROM schema.table IN CLUSTER cluster;`, bc.Create())
+	r.Equal(`CREATE SINK "database"."schema"."sink" FROM "schema"."table" IN CLUSTER "cluster";`, bc.Create())
 }
 
 func TestResourceSinkCreateKafka(t *testing.T) {
 	r := require.New(t)
-	b := newSinkBuilder("sink", "schema")
+	b := newSinkBuilder("sink", "schema", "database")
 	b.Size("xsmall")
 	b.ItemName("schema.table")
 	b.KafkaConnection("kafka_connection")
@@ -30,47 +33,134 @@ func TestResourceSinkCreateKafka(t *testing.T) {
 	b.Format("AVRO")
 	b.SchemaRegistryConnection("csr_connection")
 	b.Envelope("UPSERT")
-	r.Equal(`CREATE SINK schema.sink FROM schema.table INTO KAFKA CONNECTION kafka_connection (TOPIC 'test_avro_topic') FORMAT AVRO USING CONFLUENT SCHEMA REGISTRY CONNECTION csr_connection ENVELOPE UPSERT WITH (SIZE = 'xsmall');`, b.Create())
+	r.Equal(`CREATE SINK "database"."schema"."sink" FROM "schema"."table" INTO KAFKA CONNECTION "kafka_connection" (TOPIC 'test_avro_topic') FORMAT AVRO USING CONFLUENT SCHEMA REGISTRY CONNECTION "csr_connection" ENVELOPE UPSERT WITH (SIZE = 'xsmall');`, b.Create())
+}
+
+func TestResourceSinkRequiresSizeOrCluster(t *testing.T) {
+	r := require.New(t)
+
+	diags := Sink().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name": "sink",
+	}))
+	r.True(diags.HasError())
+
+	diags = Sink().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":         "sink",
+		"size":         "3xsmall",
+		"cluster_name": "cluster",
+	}))
+	r.True(diags.HasError())
 }
 
 func TestResourceSinkRead(t *testing.T) {
 	r := require.New(t)
-	b := newSinkBuilder("sink", "schema")
+	b := newSinkBuilder("sink", "schema", "database")
+	q, args := b.Read()
 	r.Equal(`
 		SELECT
 			mz_sinks.id,
 			mz_sinks.name,
+			mz_schemas.name as schema_name,
+			mz_databases.name as database_name,
 			mz_sinks.type,
 			mz_sinks.size,
 			mz_sinks.envelope_type,
 			mz_connections.name as connection_name,
-			mz_clusters.name as cluster_name
+			mz_clusters.name as cluster_name,
+			mz_roles.name as owner_name,
+			mz_comments.comment as comment
 		FROM mz_sinks
 		JOIN mz_schemas
 			ON mz_sinks.schema_id = mz_schemas.id
+		JOIN mz_databases
+			ON mz_schemas.database_id = mz_databases.id
+		JOIN mz_roles
+			ON mz_sinks.owner_id = mz_roles.id
+		LEFT JOIN mz_internal.mz_comments
+			ON mz_sinks.id = mz_comments.id
+			AND mz_comments.object_type = 'sink'
+			AND mz_comments.object_sub_id IS NULL
 		LEFT JOIN mz_connections
 			ON mz_sinks.connection_id = mz_connections.id
 		LEFT JOIN mz_clusters
 			ON mz_sinks.cluster_id = mz_clusters.id
-		WHERE mz_sinks.name = 'sink'
-		AND mz_schemas.name = 'schema';
-	`, b.Read())
+		WHERE mz_sinks.name = $1
+		AND mz_schemas.name = $2
+		AND mz_databases.name = $3;
+	`, q)
+	r.Equal([]interface{}{"sink", "schema", "database"}, args)
+}
+
+func TestResourceSinkReadStatus(t *testing.T) {
+	r := require.New(t)
+	b := newSinkBuilder("sink", "schema", "database")
+	q, args := b.ReadStatus("u1")
+	r.Equal(`
+		SELECT mz_sink_statuses.status, mz_sink_statuses.error
+		FROM mz_internal.mz_sink_statuses
+		WHERE mz_sink_statuses.id = $1;
+	`, q)
+	r.Equal([]interface{}{"u1"}, args)
 }
 
 func TestResourceSinkRename(t *testing.T) {
 	r := require.New(t)
-	b := newSinkBuilder("sink", "schema")
-	r.Equal(`ALTER SINK schema.sink RENAME TO schema.new_sink;`, b.Rename("new_sink"))
+	b := newSinkBuilder("sink", "schema", "database")
+	r.Equal(`ALTER SINK "database"."schema"."sink" RENAME TO "new_sink";`, b.Rename("new_sink"))
 }
 
 func TestResourceSinkResize(t *testing.T) {
 	r := require.New(t)
-	b := newSinkBuilder("sink", "schema")
-	r.Equal(`ALTER SINK schema.sink SET (SIZE = 'xlarge');`, b.UpdateSize("xlarge"))
+	b := newSinkBuilder("sink", "schema", "database")
+	r.Equal(`ALTER SINK "database"."schema"."sink" SET (SIZE = 'xlarge');`, b.UpdateSize("xlarge"))
+}
+
+func TestResourceSinkAlterOwner(t *testing.T) {
+	r := require.New(t)
+	b := newSinkBuilder("sink", "schema", "database")
+	r.Equal(`ALTER SINK "database"."schema"."sink" OWNER TO "role";`, b.AlterOwner("role"))
+}
+
+func TestResourceSinkComment(t *testing.T) {
+	r := require.New(t)
+	b := newSinkBuilder("sink", "schema", "database")
+	r.Equal(`COMMENT ON SINK "database"."schema"."sink" IS 'A comment';`, b.Comment("A comment"))
+}
+
+func TestResourceSinkCommentRemove(t *testing.T) {
+	r := require.New(t)
+	b := newSinkBuilder("sink", "schema", "database")
+	r.Equal(`COMMENT ON SINK "database"."schema"."sink" IS NULL;`, b.Comment(""))
 }
 
 func TestResourceSinkDrop(t *testing.T) {
 	r := require.New(t)
-	b := newSinkBuilder("sink", "schema")
-	r.Equal(`DROP SINK schema.sink;`, b.Drop())
+	b := newSinkBuilder("sink", "schema", "database")
+	r.Equal(`DROP SINK "database"."schema"."sink";`, b.Drop())
+}
+
+func TestResourceSinkImport(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(`SELECT\s+mz_sinks.id`).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name", "schema_name", "database_name", "type", "size", "envelope_type", "connection_name", "cluster_name", "owner_name", "comment"}).
+				AddRow("u1", "sink", "public", "materialize", "kafka", nil, "debezium", "kafka_conn", "cluster", "mz_system", nil),
+		)
+		mock.ExpectQuery(`SHOW CREATE SINK "materialize"."public"."sink";`).WillReturnRows(
+			sqlmock.NewRows([]string{"name", "create_sql"}).AddRow("materialize.public.sink", `CREATE SINK "materialize"."public"."sink" IN CLUSTER "cluster" FROM "materialize"."public"."table" INTO KAFKA CONNECTION "materialize"."public"."kafka_conn" (TOPIC = 'events') FORMAT JSON ENVELOPE DEBEZIUM`),
+		)
+
+		diff := ImportPlan(t, Sink(), "materialize.public.sink", map[string]interface{}{
+			"name":             "sink",
+			"cluster_name":     "cluster",
+			"item_name":        "table",
+			"kafka_connection": "kafka_conn",
+			"topic":            "events",
+			"format":           "json",
+			"envelope":         "debezium",
+			"ownership_role":   "mz_system",
+		}, testMeta(db))
+		r.True(diff.Empty(), "unexpected diff: %v", diff)
+	})
 }
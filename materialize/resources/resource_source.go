@@ -4,8 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"log"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -13,7 +17,7 @@ import (
 )
 
 func Source() *schema.Resource {
-	return &schema.Resource{
+	return importDefaults(&schema.Resource{
 		Description: "Load generator sources produce synthetic data for use in demos and performance tests.",
 
 		CreateContext: resourceSourceCreate,
@@ -21,6 +25,19 @@ func Source() *schema.Resource {
 		UpdateContext: resourceSourceUpdate,
 		DeleteContext: resourceSourceDelete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: importQualifiedName(resourceSourceRead, "database_name", "schema_name", "name"),
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		CustomizeDiff: defaultDatabaseName,
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Description: "The identifier for the source.",
@@ -31,28 +48,38 @@ func Source() *schema.Resource {
 				Description: "The identifier for the source schema.",
 				Type:        schema.TypeString,
 				Optional:    true,
+				ForceNew:    true,
 				Default:     "public",
 			},
+			"database_name": {
+				Description: "The identifier for the source database. Defaults to the database of the provider configuration.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
 			"cluster_name": {
-				Description:   "The cluster to maintain this source. If not specified, the size option must be specified.",
-				Type:          schema.TypeString,
-				Optional:      true,
-				ConflictsWith: []string{"size"},
+				Description:  "The cluster to maintain this source. If not specified, the size option must be specified.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"cluster_name", "size"},
 			},
 			"size": {
-				Description:   "The size of the source.",
-				Type:          schema.TypeString,
-				Optional:      true,
-				ForceNew:      true,
-				ValidateFunc:  validation.StringInSlice(sourceSizes, true),
-				ConflictsWith: []string{"cluster_name"},
+				Description:      "The size of the source.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressCaseDiff,
+				ForceNew:         true,
+				ValidateFunc:     validation.StringInSlice(sourceSizes, true),
+				ExactlyOneOf:     []string{"cluster_name", "size"},
 			},
 			"connection_type": {
-				Description:  "The source connection type.",
-				Type:         schema.TypeString,
-				Required:     true,
-				ForceNew:     true,
-				ValidateFunc: validation.StringInSlice(connectionTypes, true),
+				Description:      "The source connection type.",
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: suppressCaseDiff,
+				ForceNew:         true,
+				ValidateFunc:     validation.StringInSlice(connectionTypes, true),
 			},
 			// Load Generator
 			"load_generator_type": {
@@ -78,12 +105,13 @@ func Source() *schema.Resource {
 			},
 			// Postgres
 			"postgres_connection": {
-				Description:   "The name of the PostgreSQL connection to use in the source.",
-				Type:          schema.TypeString,
-				Optional:      true,
-				ForceNew:      true,
-				ConflictsWith: []string{"kafka_connection", "load_generator_type"},
-				RequiredWith:  []string{"postgres_connection", "publication"},
+				Description:      "The name of the PostgreSQL connection to use in the source.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressQualifiedNameDiff,
+				ForceNew:         true,
+				ConflictsWith:    []string{"kafka_connection", "load_generator_type"},
+				RequiredWith:     []string{"postgres_connection", "publication"},
 			},
 			"publication": {
 				Description:   "The PostgreSQL publication (the replication data set containing the tables to be streamed to Materialize).",
@@ -99,18 +127,18 @@ func Source() *schema.Resource {
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
-				Optional:     true,
-				ForceNew:     true,
-				ValidateFunc: validation.StringInSlice(replicaSizes, true),
+				Optional: true,
+				ForceNew: true,
 			},
 			// Broker
 			"kafka_connection": {
-				Description:   "The name of the Kafka connection to use in the source.",
-				Type:          schema.TypeString,
-				Optional:      true,
-				ForceNew:      true,
-				ConflictsWith: []string{"load_generator_type", "postgres_connection"},
-				RequiredWith:  []string{"kafka_connection", "topic"},
+				Description:      "The name of the Kafka connection to use in the source.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressQualifiedNameDiff,
+				ForceNew:         true,
+				ConflictsWith:    []string{"load_generator_type", "postgres_connection"},
+				RequiredWith:     []string{"kafka_connection", "topic"},
 			},
 			"topic": {
 				Description:   "The Kafka topic you want to subscribe to.",
@@ -145,31 +173,52 @@ func Source() *schema.Resource {
 				ForceNew:    true,
 			},
 			"format": {
-				Description: "How to decode raw bytes from different formats into data structures it can understand at runtime",
-				Type:        schema.TypeString,
-				Optional:    true,
-				ForceNew:    true,
+				Description:      "How to decode raw bytes from different formats into data structures it can understand at runtime",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressCaseDiff,
+				ForceNew:         true,
 			},
 			"envelope": {
-				Description:  "How to interpret records (e.g. Append Only, Upsert).",
-				Type:         schema.TypeString,
-				Optional:     true,
-				ForceNew:     true,
-				ValidateFunc: validation.StringInSlice(envelopes, true),
+				Description:      "How to interpret records (e.g. Append Only, Upsert).",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressCaseDiff,
+				ForceNew:         true,
+				ValidateFunc:     validation.StringInSlice(envelopes, true),
 			},
 			"schema_registry_connection": {
-				Description: "The name of the connection to use for the shcema registry.",
+				Description:      "The name of the connection to use for the shcema registry.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressQualifiedNameDiff,
+				ForceNew:         true,
+			},
+			"wait_for_ready": {
+				Description: "Wait until the source is running before completing the create.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"ownership_role": {
+				Description: "The owner of the object.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"comment": {
+				Description: "A comment describing the object.",
 				Type:        schema.TypeString,
 				Optional:    true,
-				ForceNew:    true,
 			},
 		},
-	}
+	})
 }
 
 type SourceBuilder struct {
 	sourceName               string
 	schemaName               string
+	databaseName             string
 	clusterName              string
 	size                     string
 	connectionType           string
@@ -190,10 +239,11 @@ type SourceBuilder struct {
 	schemaRegistryConnection string
 }
 
-func newSourceBuilder(sourceName, schemaName string) *SourceBuilder {
+func newSourceBuilder(sourceName, schemaName, databaseName string) *SourceBuilder {
 	return &SourceBuilder{
-		sourceName: sourceName,
-		schemaName: schemaName,
+		sourceName:   sourceName,
+		schemaName:   schemaName,
+		databaseName: databaseName,
 	}
 }
 
@@ -289,7 +339,7 @@ func (b *SourceBuilder) SchemaRegistryConnection(s string) *SourceBuilder {
 
 func (b *SourceBuilder) Create() string {
 	q := strings.Builder{}
-	q.WriteString(fmt.Sprintf(`CREATE SOURCE %s.%s`, b.schemaName, b.sourceName))
+	q.WriteString(fmt.Sprintf(`CREATE SOURCE %s`, qualifiedName(b.databaseName, b.schemaName, b.sourceName)))
 
 	if b.connectionType != "" {
 		q.WriteString(fmt.Sprintf(` FROM %s`, b.connectionType))
@@ -301,7 +351,7 @@ func (b *SourceBuilder) Create() string {
 
 		var p []string
 		if b.tickInterval != "" {
-			t := fmt.Sprintf(`TICK INTERVAL '%s'`, b.tickInterval)
+			t := fmt.Sprintf(`TICK INTERVAL %s`, quoteString(b.tickInterval))
 			p = append(p, t)
 		}
 
@@ -318,7 +368,7 @@ func (b *SourceBuilder) Create() string {
 
 	// Postgres
 	if b.connectionType == "POSTGRES" {
-		q.WriteString(fmt.Sprintf(` CONNECTION %s (PUBLICATION '%s')`, b.postgresConnection, b.publication))
+		q.WriteString(fmt.Sprintf(` CONNECTION %s (PUBLICATION %s)`, quoteReference(b.postgresConnection), quoteString(b.publication)))
 
 		var o []string
 		if len(b.tables) > 0 {
@@ -330,7 +380,7 @@ func (b *SourceBuilder) Create() string {
 			sort.Strings(keys)
 
 			for _, k := range keys {
-				s := fmt.Sprintf(`%s AS %s`, k, b.tables[k])
+				s := fmt.Sprintf(`%s AS %s`, quoteReference(k), quoteIdentifier(b.tables[k]))
 				o = append(o, s)
 			}
 			o := strings.Join(o[:], ", ")
@@ -342,14 +392,30 @@ func (b *SourceBuilder) Create() string {
 
 	// Broker
 	if b.connectionType == "KAFKA" {
-		q.WriteString(fmt.Sprintf(` CONNECTION %s (TOPIC '%s')`, b.kafkaConnection, b.topic))
+		q.WriteString(fmt.Sprintf(` CONNECTION %s (TOPIC %s)`, quoteReference(b.kafkaConnection), quoteString(b.topic)))
 
 		if b.format != "" {
 			q.WriteString(fmt.Sprintf(` FORMAT %s`, b.format))
 		}
 
 		if b.schemaRegistryConnection != "" {
-			q.WriteString(fmt.Sprintf(` USING CONFLUENT SCHEMA REGISTRY CONNECTION %s`, b.schemaRegistryConnection))
+			q.WriteString(fmt.Sprintf(` USING CONFLUENT SCHEMA REGISTRY CONNECTION %s`, quoteReference(b.schemaRegistryConnection)))
+		}
+
+		var i []string
+		for _, c := range []struct{ metadata, column string }{
+			{"KEY", b.includeKey},
+			{"PARTITION", b.includePartition},
+			{"OFFSET", b.includeOffset},
+			{"TIMESTAMP", b.includeTimestamp},
+		} {
+			if c.column != "" {
+				i = append(i, fmt.Sprintf(`%s AS %s`, c.metadata, quoteIdentifier(c.column)))
+			}
+		}
+
+		if len(i) != 0 {
+			q.WriteString(fmt.Sprintf(` INCLUDE %s`, strings.Join(i, ", ")))
 		}
 
 		if b.envelope != "" {
@@ -358,76 +424,250 @@ func (b *SourceBuilder) Create() string {
 	}
 
 	if b.size != "" {
-		q.WriteString(fmt.Sprintf(` WITH (SIZE = '%s')`, b.size))
+		q.WriteString(fmt.Sprintf(` WITH (SIZE = %s)`, quoteString(b.size)))
 	} else if b.clusterName != "" {
-		q.WriteString(fmt.Sprintf(` IN CLUSTER %s`, b.clusterName))
-	} else {
-		panic(`Must include either size or cluster`)
+		q.WriteString(fmt.Sprintf(` IN CLUSTER %s`, quoteIdentifier(b.clusterName)))
 	}
 
 	q.WriteString(`;`)
 	return q.String()
 }
 
-func (b *SourceBuilder) Read() string {
-	return fmt.Sprintf(`
+func (b *SourceBuilder) Read() (string, []interface{}) {
+	return `
 		SELECT
 			mz_sources.id,
 			mz_sources.name,
+			mz_schemas.name as schema_name,
+			mz_databases.name as database_name,
 			mz_sources.type,
 			mz_sources.size,
 			mz_sources.envelope_type,
 			mz_connections.name as connection_name,
-			mz_clusters.name as cluster_name
+			mz_clusters.name as cluster_name,
+			mz_roles.name as owner_name,
+			mz_comments.comment as comment
 		FROM mz_sources
 		JOIN mz_schemas
 			ON mz_sources.schema_id = mz_schemas.id
+		JOIN mz_databases
+			ON mz_schemas.database_id = mz_databases.id
+		JOIN mz_roles
+			ON mz_sources.owner_id = mz_roles.id
+		LEFT JOIN mz_internal.mz_comments
+			ON mz_sources.id = mz_comments.id
+			AND mz_comments.object_type = 'source'
+			AND mz_comments.object_sub_id IS NULL
 		LEFT JOIN mz_connections
 			ON mz_sources.connection_id = mz_connections.id
 		LEFT JOIN mz_clusters
 			ON mz_sources.cluster_id = mz_clusters.id
-		WHERE mz_sources.name = '%s'
-		AND mz_schemas.name = '%s';
-	`, b.sourceName, b.schemaName)
+		WHERE mz_sources.name = $1
+		AND mz_schemas.name = $2
+		AND mz_databases.name = $3;
+	`, []interface{}{b.sourceName, b.schemaName, b.databaseName}
+}
+
+func (b *SourceBuilder) ShowCreate() string {
+	return fmt.Sprintf(`SHOW CREATE SOURCE %s;`, qualifiedName(b.databaseName, b.schemaName, b.sourceName))
+}
+
+func (b *SourceBuilder) ReadStatus(id string) (string, []interface{}) {
+	return `
+		SELECT mz_source_statuses.status, mz_source_statuses.error
+		FROM mz_internal.mz_source_statuses
+		WHERE mz_source_statuses.id = $1;
+	`, []interface{}{id}
 }
 
 func (b *SourceBuilder) Rename(newName string) string {
-	return fmt.Sprintf(`ALTER SOURCE %s.%s RENAME TO %s.%s;`, b.schemaName, b.sourceName, b.schemaName, newName)
+	return fmt.Sprintf(`ALTER SOURCE %s RENAME TO %s;`, qualifiedName(b.databaseName, b.schemaName, b.sourceName), quoteIdentifier(newName))
 }
 
 func (b *SourceBuilder) UpdateSize(newSize string) string {
-	return fmt.Sprintf(`ALTER SOURCE %s.%s SET (SIZE = '%s');`, b.schemaName, b.sourceName, newSize)
+	return fmt.Sprintf(`ALTER SOURCE %s SET (SIZE = %s);`, qualifiedName(b.databaseName, b.schemaName, b.sourceName), quoteString(newSize))
+}
+
+func (b *SourceBuilder) AlterOwner(roleName string) string {
+	return fmt.Sprintf(`ALTER SOURCE %s OWNER TO %s;`, qualifiedName(b.databaseName, b.schemaName, b.sourceName), quoteIdentifier(roleName))
+}
+
+func (b *SourceBuilder) Comment(comment string) string {
+	if comment == "" {
+		return fmt.Sprintf(`COMMENT ON SOURCE %s IS NULL;`, qualifiedName(b.databaseName, b.schemaName, b.sourceName))
+	}
+	return fmt.Sprintf(`COMMENT ON SOURCE %s IS %s;`, qualifiedName(b.databaseName, b.schemaName, b.sourceName), quoteString(comment))
 }
 
 func (b *SourceBuilder) Drop() string {
-	return fmt.Sprintf(`DROP SOURCE %s.%s;`, b.schemaName, b.sourceName)
+	return fmt.Sprintf(`DROP SOURCE %s;`, qualifiedName(b.databaseName, b.schemaName, b.sourceName))
+}
+
+var (
+	loadGeneratorClause = regexp.MustCompile(`\bFROM LOAD GENERATOR (\w+)`)
+	scaleFactorClause   = regexp.MustCompile(`\bSCALE FACTOR(?: = | )([0-9.]+)`)
+	includeClause       = regexp.MustCompile(`\bINCLUDE (.+?)(?: ENVELOPE | WITH \(| IN CLUSTER | EXPOSE |;|$)`)
+	includeMetadata     = regexp.MustCompile(`^(KEY|PARTITION|OFFSET|TIMESTAMP)(?: AS (.+))?$`)
+	tablesClause        = regexp.MustCompile(`\bFOR TABLES \(`)
+)
+
+// Returns the columns of the INCLUDE clause of a recorded CREATE SOURCE
+// statement keyed by the metadata they hold, e.g. KEY AS "k" as KEY: k.
+// Metadata included without an alias keeps its default column name.
+func createSqlIncludes(createSql string) map[string]string {
+	includes := map[string]string{}
+	m := includeClause.FindStringSubmatchIndex(maskQuoted(createSql))
+	if m == nil {
+		return includes
+	}
+
+	for _, e := range splitList("(" + createSql[m[2]:m[3]] + ")") {
+		i := includeMetadata.FindStringSubmatchIndex(maskQuoted(e))
+		if i == nil {
+			continue
+		}
+		metadata := e[i[2]:i[3]]
+		if i[4] == -1 {
+			includes[metadata] = strings.ToLower(metadata)
+		} else {
+			includes[metadata] = unquoteValue(e[i[4]:i[5]])
+		}
+	}
+	return includes
+}
+
+// Returns the upstream tables of the FOR TABLES clause of a recorded CREATE
+// SOURCE statement mapped to the names of their subsources
+func createSqlTables(createSql string) map[string]string {
+	masked := maskQuoted(createSql)
+	loc := tablesClause.FindStringIndex(masked)
+	if loc == nil {
+		return nil
+	}
+
+	start, depth := loc[1]-1, 0
+	end := -1
+	for i := start; i < len(masked) && end == -1; i++ {
+		switch masked[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				end = i + 1
+			}
+		}
+	}
+	if end == -1 {
+		return nil
+	}
+
+	tables := map[string]string{}
+	for _, e := range splitList(createSql[start:end]) {
+		i := strings.Index(maskQuoted(e), " AS ")
+		if i == -1 {
+			continue
+		}
+		// Subsources may be recorded fully qualified, while they are
+		// configured by name alone
+		alias := splitQualifiedName(strings.TrimSpace(e[i+4:]))
+		tables[unquoteValue(strings.TrimSpace(e[:i]))] = unquoteValue(alias[len(alias)-1])
+	}
+	return tables
 }
 
 func resourceSourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	conn := meta.(*sql.DB)
+	conn := meta.(*ProviderMeta)
 	sourceName := d.Get("name").(string)
 	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
+
+	builder := newSourceBuilder(sourceName, schemaName, databaseName)
+	q, args := builder.Read()
+
+	var id, name, schema_name, database_name, source_type, owner_name string
+	var size, envelope_type, connection_name, cluster_name, comment sql.NullString
+	if err := queryRow(ctx, conn, q, args, &id, &name, &schema_name, &database_name, &source_type, &size, &envelope_type, &connection_name, &cluster_name, &owner_name, &comment); err == sql.ErrNoRows {
+		log.Printf("[WARN] source (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return diags
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
 
-	builder := newSourceBuilder(sourceName, schemaName)
-	q := builder.Read()
+	d.SetId(id)
+	d.Set("name", name)
+	d.Set("schema_name", schema_name)
+	d.Set("database_name", database_name)
 
-	var id, name, source_type, size, envelope_type, connection_name, cluster_name string
-	conn.QueryRow(q).Scan(&id, &name, &source_type, &size, &envelope_type, &connection_name, &cluster_name)
+	if size.Valid {
+		d.Set("size", size.String)
+	} else {
+		d.Set("cluster_name", cluster_name.String)
+	}
 
-	d.SetId(id)
+	connectionType := sourceConnectionTypes[source_type]
+	d.Set("connection_type", connectionType)
+
+	// Options without a catalog column are read from the recorded statement
+	createSql, err := readCreateSql(ctx, conn, builder.ShowCreate())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	switch connectionType {
+	case "KAFKA":
+		d.Set("kafka_connection", connection_name.String)
+		d.Set("topic", createSqlValue(createSql, "TOPIC"))
+		d.Set("format", createSqlFormat(createSql))
+		d.Set("schema_registry_connection", createSqlValue(createSql, "USING CONFLUENT SCHEMA REGISTRY CONNECTION"))
+
+		includes := createSqlIncludes(createSql)
+		d.Set("include_key", includes["KEY"])
+		d.Set("include_partition", includes["PARTITION"])
+		d.Set("include_offset", includes["OFFSET"])
+		d.Set("include_timestamp", includes["TIMESTAMP"])
+	case "POSTGRES":
+		d.Set("postgres_connection", connection_name.String)
+		d.Set("publication", createSqlValue(createSql, "PUBLICATION"))
+		d.Set("tables", createSqlTables(createSql))
+	case "LOAD GENERATOR":
+		masked := maskQuoted(createSql)
+		if m := loadGeneratorClause.FindStringSubmatch(masked); m != nil {
+			d.Set("load_generator_type", m[1])
+		}
+		d.Set("tick_interval", createSqlValue(createSql, "TICK INTERVAL"))
+
+		// Materialize records no scale factor for generators created without one
+		scaleFactor := 0.01
+		if m := scaleFactorClause.FindStringSubmatch(masked); m != nil {
+			if f, err := strconv.ParseFloat(m[1], 64); err == nil {
+				scaleFactor = f
+			}
+		}
+		d.Set("scale_factor", scaleFactor)
+	}
+
+	if envelope_type.Valid && envelope_type.String != "none" {
+		d.Set("envelope", strings.ToUpper(envelope_type.String))
+	}
+
+	d.Set("ownership_role", owner_name)
+	d.Set("comment", comment.String)
 
 	return diags
 }
 
 func resourceSourceCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*sql.DB)
+	conn := meta.(*ProviderMeta)
 
 	sourceName := d.Get("name").(string)
 	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
 
-	builder := newSourceBuilder(sourceName, schemaName)
+	builder := newSourceBuilder(sourceName, schemaName, databaseName)
 
 	if v, ok := d.GetOk("cluster_name"); ok {
 		builder.ClusterName(v.(string))
@@ -438,7 +678,7 @@ func resourceSourceCreate(ctx context.Context, d *schema.ResourceData, meta any)
 	}
 
 	if v, ok := d.GetOk("connection_type"); ok {
-		builder.ConnectionType(v.(string))
+		builder.ConnectionType(strings.ToUpper(v.(string)))
 	}
 
 	if v, ok := d.GetOk("load_generator_type"); ok {
@@ -449,20 +689,24 @@ func resourceSourceCreate(ctx context.Context, d *schema.ResourceData, meta any)
 		builder.TickInterval(v.(string))
 	}
 
-	if v, ok := d.GetOk("tick_interval"); ok {
-		builder.TickInterval(v.(string))
-	}
-
 	if v, ok := d.GetOk("scale_factor"); ok {
 		builder.ScaleFactor(v.(float64))
 	}
 
+	if v, ok := d.GetOk("postgres_connection"); ok {
+		builder.PostgresConnection(v.(string))
+	}
+
 	if v, ok := d.GetOk("publication"); ok {
 		builder.Publication(v.(string))
 	}
 
 	if v, ok := d.GetOk("tables"); ok {
-		builder.Tables(v.(map[string]string))
+		tables := map[string]string{}
+		for k, t := range v.(map[string]interface{}) {
+			tables[k] = t.(string)
+		}
+		builder.Tables(tables)
 	}
 
 	if v, ok := d.GetOk("kafka_connection"); ok {
@@ -499,46 +743,100 @@ func resourceSourceCreate(ctx context.Context, d *schema.ResourceData, meta any)
 
 	q := builder.Create()
 
-	ExecResource(conn, q)
-	return resourceSourceRead(ctx, d, meta)
+	if diags := ExecResource(ctx, conn, q); diags.HasError() {
+		return diags
+	}
+
+	if v, ok := d.GetOk("ownership_role"); ok {
+		if diags := ExecResource(ctx, conn, builder.AlterOwner(v.(string))); diags.HasError() {
+			return diags
+		}
+	}
+
+	if v, ok := d.GetOk("comment"); ok {
+		if diags := ExecResource(ctx, conn, builder.Comment(v.(string))); diags.HasError() {
+			return diags
+		}
+	}
+
+	diags := resourceSourceRead(ctx, d, meta)
+	if diags.HasError() || d.Id() == "" {
+		return diags
+	}
+
+	if d.Get("wait_for_ready").(bool) {
+		q, args := builder.ReadStatus(d.Id())
+		if err := waitForReady(ctx, conn, d.Timeout(schema.TimeoutCreate), fmt.Sprintf("source %s", sourceName), "running", q, args...); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return diags
 }
 
 func resourceSourceUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*sql.DB)
-	schemaName := d.Get("name").(string)
+	conn := meta.(*ProviderMeta)
+	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
 
 	if d.HasChange("name") {
 		oldName, newName := d.GetChange("name")
 
-		builder := newSourceBuilder(oldName.(string), schemaName)
+		builder := newSourceBuilder(oldName.(string), schemaName, databaseName)
 		q := builder.Rename(newName.(string))
 
-		ExecResource(conn, q)
+		if diags := ExecResource(ctx, conn, q); diags.HasError() {
+			return diags
+		}
 	}
 
 	if d.HasChange("size") {
-		sourceName := d.Get("sourceName").(string)
+		sourceName := d.Get("name").(string)
 		_, newSize := d.GetChange("size")
 
-		builder := newSourceBuilder(sourceName, schemaName)
+		builder := newSourceBuilder(sourceName, schemaName, databaseName)
 		q := builder.UpdateSize(newSize.(string))
 
-		ExecResource(conn, q)
+		if diags := ExecResource(ctx, conn, q); diags.HasError() {
+			return diags
+		}
+	}
+
+	if d.HasChange("ownership_role") {
+		sourceName := d.Get("name").(string)
+		_, newRole := d.GetChange("ownership_role")
+
+		builder := newSourceBuilder(sourceName, schemaName, databaseName)
+		q := builder.AlterOwner(newRole.(string))
+
+		if diags := ExecResource(ctx, conn, q); diags.HasError() {
+			return diags
+		}
+	}
+
+	if d.HasChange("comment") {
+		sourceName := d.Get("name").(string)
+		_, newComment := d.GetChange("comment")
+
+		builder := newSourceBuilder(sourceName, schemaName, databaseName)
+		q := builder.Comment(newComment.(string))
+
+		if diags := ExecResource(ctx, conn, q); diags.HasError() {
+			return diags
+		}
 	}
 
-	return resourceSecretRead(ctx, d, meta)
+	return resourceSourceRead(ctx, d, meta)
 }
 
 func resourceSourceDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	var diags diag.Diagnostics
-
-	conn := meta.(*sql.DB)
+	conn := meta.(*ProviderMeta)
 	sourceName := d.Get("name").(string)
 	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
 
-	builder := newSourceBuilder(sourceName, schemaName)
+	builder := newSourceBuilder(sourceName, schemaName, databaseName)
 	q := builder.Drop()
 
-	ExecResource(conn, q)
-	return diags
+	return ExecResource(ctx, conn, q)
 }
@@ -1,47 +1,52 @@
 package resources
 
 import (
+	"context"
+	"database/sql"
 	"testing"
 
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/require"
 )
 
 func TestResourceSourceCreate(t *testing.T) {
 	r := require.New(t)
 
-	bs := newSourceBuilder("source", "schema")
+	bs := newSourceBuilder("source", "schema", "database")
 	bs.Size("xsmall")
-	r.Equal(`CREATE SOURCE schema.source WITH (SIZE = 'xsmall');`, bs.Create())
+	r.Equal(`CREATE SOURCE "database"."schema"."source" WITH (SIZE = 'xsmall');`, bs.Create())
 
-	bc := newSourceBuilder("source", "schema")
+	bc := newSourceBuilder("source", "schema", "database")
 	bc.ClusterName("cluster")
-	r.Equal(`CREATE SOURCE schema.source IN CLUSTER cluster;`, bc.Create())
+	r.Equal(`CREATE SOURCE "database"."schema"."source" IN CLUSTER "cluster";`, bc.Create())
 }
 
 func TestResourceSourceCreateLoadGenerator(t *testing.T) {
 	r := require.New(t)
-	b := newSourceBuilder("source", "schema")
+	b := newSourceBuilder("source", "schema", "database")
 	b.Size("xsmall")
 	b.ConnectionType("LOAD GENERATOR")
 	b.LoadGeneratorType("TPCH")
 	b.TickInterval("1s")
 	b.ScaleFactor(0.01)
-	r.Equal(`CREATE SOURCE schema.source FROM LOAD GENERATOR TPCH (TICK INTERVAL '1s', SCALE FACTOR 0.01) WITH (SIZE = 'xsmall');`, b.Create())
+	r.Equal(`CREATE SOURCE "database"."schema"."source" FROM LOAD GENERATOR TPCH (TICK INTERVAL '1s', SCALE FACTOR 0.01) WITH (SIZE = 'xsmall');`, b.Create())
 }
 
 func TestResourceSourceCreatePostgres(t *testing.T) {
 	r := require.New(t)
-	b := newSourceBuilder("source", "schema")
+	b := newSourceBuilder("source", "schema", "database")
 	b.Size("xsmall")
 	b.ConnectionType("POSTGRES")
 	b.PostgresConnection("pg_connection")
 	b.Publication("mz_source")
-	r.Equal(`CREATE SOURCE schema.source FROM POSTGRES CONNECTION pg_connection (PUBLICATION 'mz_source') FOR ALL TABLES WITH (SIZE = 'xsmall');`, b.Create())
+	r.Equal(`CREATE SOURCE "database"."schema"."source" FROM POSTGRES CONNECTION "pg_connection" (PUBLICATION 'mz_source') FOR ALL TABLES WITH (SIZE = 'xsmall');`, b.Create())
 }
 
 func TestResourceSourceCreatePostgresTables(t *testing.T) {
 	r := require.New(t)
-	b := newSourceBuilder("source", "schema")
+	b := newSourceBuilder("source", "schema", "database")
 	b.Size("xsmall")
 	b.ConnectionType("POSTGRES")
 	b.PostgresConnection("pg_connection")
@@ -50,12 +55,23 @@ func TestResourceSourceCreatePostgresTables(t *testing.T) {
 		"schema1.table_1": "s1_table_1",
 		"schema2_table_1": "s2_table_1",
 	})
-	r.Equal(`CREATE SOURCE schema.source FROM POSTGRES CONNECTION pg_connection (PUBLICATION 'mz_source') FOR TABLES (schema1.table_1 AS s1_table_1, schema2_table_1 AS s2_table_1) WITH (SIZE = 'xsmall');`, b.Create())
+	r.Equal(`CREATE SOURCE "database"."schema"."source" FROM POSTGRES CONNECTION "pg_connection" (PUBLICATION 'mz_source') FOR TABLES ("schema1"."table_1" AS "s1_table_1", "schema2_table_1" AS "s2_table_1") WITH (SIZE = 'xsmall');`, b.Create())
+}
+
+func TestResourceSourceCreateUnusualNames(t *testing.T) {
+	r := require.New(t)
+	b := newSourceBuilder("Source-1", "My Schema", "database")
+	b.ClusterName("Cluster")
+	b.ConnectionType("KAFKA")
+	b.KafkaConnection("My Schema.Kafka-Conn")
+	b.Topic("it's-a-topic")
+	b.Format("JSON")
+	r.Equal(`CREATE SOURCE "database"."My Schema"."Source-1" FROM KAFKA CONNECTION "My Schema"."Kafka-Conn" (TOPIC 'it''s-a-topic') FORMAT JSON IN CLUSTER "Cluster";`, b.Create())
 }
 
 func TestResourceSourceCreateKafka(t *testing.T) {
 	r := require.New(t)
-	b := newSourceBuilder("source", "schema")
+	b := newSourceBuilder("source", "schema", "database")
 	b.Size("xsmall")
 	b.ConnectionType("KAFKA")
 	b.KafkaConnection("kafka_connection")
@@ -63,47 +79,266 @@ func TestResourceSourceCreateKafka(t *testing.T) {
 	b.Format("AVRO")
 	b.SchemaRegistryConnection("csr_connection")
 	b.Envelope("UPSERT")
-	r.Equal(`CREATE SOURCE schema.source FROM KAFKA CONNECTION kafka_connection (TOPIC 'events') FORMAT AVRO USING CONFLUENT SCHEMA REGISTRY CONNECTION csr_connection ENVELOPE UPSERT WITH (SIZE = 'xsmall');`, b.Create())
+	r.Equal(`CREATE SOURCE "database"."schema"."source" FROM KAFKA CONNECTION "kafka_connection" (TOPIC 'events') FORMAT AVRO USING CONFLUENT SCHEMA REGISTRY CONNECTION "csr_connection" ENVELOPE UPSERT WITH (SIZE = 'xsmall');`, b.Create())
+}
+
+func TestResourceSourceCreateKafkaInclude(t *testing.T) {
+	r := require.New(t)
+	b := newSourceBuilder("source", "schema", "database")
+	b.Size("xsmall")
+	b.ConnectionType("KAFKA")
+	b.KafkaConnection("kafka_connection")
+	b.Topic("events")
+	b.Format("JSON")
+	b.IncludeKey("message_key")
+	b.IncludeOffset("offset")
+	b.Envelope("UPSERT")
+	r.Equal(`CREATE SOURCE "database"."schema"."source" FROM KAFKA CONNECTION "kafka_connection" (TOPIC 'events') FORMAT JSON INCLUDE KEY AS "message_key", OFFSET AS "offset" ENVELOPE UPSERT WITH (SIZE = 'xsmall');`, b.Create())
+}
+
+func TestResourceSourceRequiresSizeOrCluster(t *testing.T) {
+	r := require.New(t)
+
+	diags := Source().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":                "source",
+		"connection_type":     "LOAD GENERATOR",
+		"load_generator_type": "COUNTER",
+	}))
+	r.True(diags.HasError())
+
+	diags = Source().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":                "source",
+		"cluster_name":        "cluster",
+		"connection_type":     "LOAD GENERATOR",
+		"load_generator_type": "COUNTER",
+	}))
+	r.False(diags.HasError())
+}
+
+func TestResourceSourceCreatePostgresTablesData(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.MatchExpectationsInOrder(true)
+		mock.ExpectExec(`CREATE SOURCE "database"."schema"."source" FROM POSTGRES CONNECTION "pg_conn" \(PUBLICATION 'mz_source'\) FOR TABLES \("schema1"."table_1" AS "s1_table_1"\) IN CLUSTER "cluster";`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery(`SELECT\s+mz_sources.id`).WithArgs("source", "schema", "database").WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name", "schema_name", "database_name", "type", "size", "envelope_type", "connection_name", "cluster_name", "owner_name", "comment"}).
+				AddRow("u1", "source", "schema", "database", "postgres", nil, nil, "pg_conn", "cluster", "mz_system", nil),
+		)
+		mock.ExpectQuery(`SHOW CREATE SOURCE "database"."schema"."source";`).WillReturnRows(
+			sqlmock.NewRows([]string{"name", "create_sql"}).AddRow("database.schema.source", `CREATE SOURCE "database"."schema"."source" IN CLUSTER "cluster" FROM POSTGRES CONNECTION "database"."schema"."pg_conn" (PUBLICATION = 'mz_source') FOR TABLES ("schema1"."table_1" AS "database"."schema"."s1_table_1")`),
+		)
+
+		d := schema.TestResourceDataRaw(t, Source().Schema, map[string]interface{}{
+			"name":                "source",
+			"schema_name":         "schema",
+			"database_name":       "database",
+			"cluster_name":        "cluster",
+			"connection_type":     "POSTGRES",
+			"postgres_connection": "pg_conn",
+			"publication":         "mz_source",
+			"tables":              map[string]interface{}{"schema1.table_1": "s1_table_1"},
+		})
+
+		diags := resourceSourceCreate(context.TODO(), d, testMeta(db))
+		r.False(diags.HasError(), "unexpected diagnostics: %v", diags)
+		r.Equal("u1", d.Id())
+		r.Equal(map[string]interface{}{"schema1.table_1": "s1_table_1"}, d.Get("tables"))
+	})
 }
 
 func TestResourceSourceRead(t *testing.T) {
 	r := require.New(t)
-	b := newSourceBuilder("source", "schema")
+	b := newSourceBuilder("source", "schema", "database")
+	q, args := b.Read()
 	r.Equal(`
 		SELECT
 			mz_sources.id,
 			mz_sources.name,
+			mz_schemas.name as schema_name,
+			mz_databases.name as database_name,
 			mz_sources.type,
 			mz_sources.size,
 			mz_sources.envelope_type,
 			mz_connections.name as connection_name,
-			mz_clusters.name as cluster_name
+			mz_clusters.name as cluster_name,
+			mz_roles.name as owner_name,
+			mz_comments.comment as comment
 		FROM mz_sources
 		JOIN mz_schemas
 			ON mz_sources.schema_id = mz_schemas.id
+		JOIN mz_databases
+			ON mz_schemas.database_id = mz_databases.id
+		JOIN mz_roles
+			ON mz_sources.owner_id = mz_roles.id
+		LEFT JOIN mz_internal.mz_comments
+			ON mz_sources.id = mz_comments.id
+			AND mz_comments.object_type = 'source'
+			AND mz_comments.object_sub_id IS NULL
 		LEFT JOIN mz_connections
 			ON mz_sources.connection_id = mz_connections.id
 		LEFT JOIN mz_clusters
 			ON mz_sources.cluster_id = mz_clusters.id
-		WHERE mz_sources.name = 'source'
-		AND mz_schemas.name = 'schema';
-	`, b.Read())
+		WHERE mz_sources.name = $1
+		AND mz_schemas.name = $2
+		AND mz_databases.name = $3;
+	`, q)
+	r.Equal([]interface{}{"source", "schema", "database"}, args)
+}
+
+func TestResourceSourceReadStatus(t *testing.T) {
+	r := require.New(t)
+	b := newSourceBuilder("source", "schema", "database")
+	q, args := b.ReadStatus("u1")
+	r.Equal(`
+		SELECT mz_source_statuses.status, mz_source_statuses.error
+		FROM mz_internal.mz_source_statuses
+		WHERE mz_source_statuses.id = $1;
+	`, q)
+	r.Equal([]interface{}{"u1"}, args)
 }
 
 func TestResourceSourceRename(t *testing.T) {
 	r := require.New(t)
-	b := newSourceBuilder("source", "schema")
-	r.Equal(`ALTER SOURCE schema.source RENAME TO schema.new_source;`, b.Rename("new_source"))
+	b := newSourceBuilder("source", "schema", "database")
+	r.Equal(`ALTER SOURCE "database"."schema"."source" RENAME TO "new_source";`, b.Rename("new_source"))
 }
 
 func TestResourceSourceResize(t *testing.T) {
 	r := require.New(t)
-	b := newSourceBuilder("source", "schema")
-	r.Equal(`ALTER SOURCE schema.source SET (SIZE = 'xlarge');`, b.UpdateSize("xlarge"))
+	b := newSourceBuilder("source", "schema", "database")
+	r.Equal(`ALTER SOURCE "database"."schema"."source" SET (SIZE = 'xlarge');`, b.UpdateSize("xlarge"))
+}
+
+func TestResourceSourceAlterOwner(t *testing.T) {
+	r := require.New(t)
+	b := newSourceBuilder("source", "schema", "database")
+	r.Equal(`ALTER SOURCE "database"."schema"."source" OWNER TO "role";`, b.AlterOwner("role"))
+}
+
+func TestResourceSourceComment(t *testing.T) {
+	r := require.New(t)
+	b := newSourceBuilder("source", "schema", "database")
+	r.Equal(`COMMENT ON SOURCE "database"."schema"."source" IS 'A comment';`, b.Comment("A comment"))
+}
+
+func TestResourceSourceCommentRemove(t *testing.T) {
+	r := require.New(t)
+	b := newSourceBuilder("source", "schema", "database")
+	r.Equal(`COMMENT ON SOURCE "database"."schema"."source" IS NULL;`, b.Comment(""))
 }
 
 func TestResourceSourceDrop(t *testing.T) {
 	r := require.New(t)
-	b := newSourceBuilder("source", "schema")
-	r.Equal(`DROP SOURCE schema.source;`, b.Drop())
+	b := newSourceBuilder("source", "schema", "database")
+	r.Equal(`DROP SOURCE "database"."schema"."source";`, b.Drop())
+}
+
+func TestResourceSourceImport(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(`SELECT\s+mz_sources.id`).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name", "schema_name", "database_name", "type", "size", "envelope_type", "connection_name", "cluster_name", "owner_name", "comment"}).
+				AddRow("u1", "source", "public", "materialize", "kafka", nil, "upsert", "kafka_conn", "cluster", "mz_system", nil),
+		)
+		mock.ExpectQuery(`SHOW CREATE SOURCE "materialize"."public"."source";`).WillReturnRows(
+			sqlmock.NewRows([]string{"name", "create_sql"}).AddRow("materialize.public.source", `CREATE SOURCE "materialize"."public"."source" IN CLUSTER "cluster" FROM KAFKA CONNECTION "materialize"."public"."kafka_conn" (TOPIC = 'events') FORMAT AVRO USING CONFLUENT SCHEMA REGISTRY CONNECTION "materialize"."public"."csr_conn" SEED VALUE SCHEMA '{}' ENVELOPE UPSERT`),
+		)
+
+		diff := ImportPlan(t, Source(), "materialize.public.source", map[string]interface{}{
+			"name":                       "source",
+			"cluster_name":               "cluster",
+			"connection_type":            "kafka",
+			"kafka_connection":           "public.kafka_conn",
+			"topic":                      "events",
+			"format":                     "AVRO",
+			"schema_registry_connection": "csr_conn",
+			"envelope":                   "upsert",
+			"ownership_role":             "mz_system",
+		}, testMeta(db))
+		r.True(diff.Empty(), "unexpected diff: %v", diff)
+	})
+}
+
+func TestResourceSourceImportKafkaInclude(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(`SELECT\s+mz_sources.id`).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name", "schema_name", "database_name", "type", "size", "envelope_type", "connection_name", "cluster_name", "owner_name", "comment"}).
+				AddRow("u1", "source", "public", "materialize", "kafka", nil, "upsert", "kafka_conn", "cluster", "mz_system", nil),
+		)
+		mock.ExpectQuery(`SHOW CREATE SOURCE "materialize"."public"."source";`).WillReturnRows(
+			sqlmock.NewRows([]string{"name", "create_sql"}).AddRow("materialize.public.source", `CREATE SOURCE "materialize"."public"."source" IN CLUSTER "cluster" FROM KAFKA CONNECTION "materialize"."public"."kafka_conn" (TOPIC = 'events') FORMAT JSON INCLUDE KEY AS "message_key", PARTITION, TIMESTAMP AS "ts" ENVELOPE UPSERT`),
+		)
+
+		diff := ImportPlan(t, Source(), "materialize.public.source", map[string]interface{}{
+			"name":              "source",
+			"cluster_name":      "cluster",
+			"connection_type":   "kafka",
+			"kafka_connection":  "kafka_conn",
+			"topic":             "events",
+			"format":            "JSON",
+			"include_key":       "message_key",
+			"include_partition": "partition",
+			"include_timestamp": "ts",
+			"envelope":          "upsert",
+			"ownership_role":    "mz_system",
+		}, testMeta(db))
+		r.True(diff.Empty(), "unexpected diff: %v", diff)
+	})
+}
+
+func TestResourceSourceImportLoadGenerator(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(`SELECT\s+mz_sources.id`).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name", "schema_name", "database_name", "type", "size", "envelope_type", "connection_name", "cluster_name", "owner_name", "comment"}).
+				AddRow("u1", "source", "public", "materialize", "load-generator", "xsmall", nil, nil, nil, "mz_system", nil),
+		)
+		mock.ExpectQuery(`SHOW CREATE SOURCE "materialize"."public"."source";`).WillReturnRows(
+			sqlmock.NewRows([]string{"name", "create_sql"}).AddRow("materialize.public.source", `CREATE SOURCE "materialize"."public"."source" FROM LOAD GENERATOR COUNTER (TICK INTERVAL = '500ms', SCALE FACTOR = 0.5) WITH (SIZE = 'xsmall')`),
+		)
+
+		diff := ImportPlan(t, Source(), "materialize.public.source", map[string]interface{}{
+			"name":                "source",
+			"size":                "xsmall",
+			"connection_type":     "LOAD GENERATOR",
+			"load_generator_type": "COUNTER",
+			"tick_interval":       "500ms",
+			"scale_factor":        0.5,
+			"ownership_role":      "mz_system",
+		}, testMeta(db))
+		r.True(diff.Empty(), "unexpected diff: %v", diff)
+	})
+}
+
+func TestResourceSourceImportPostgresTables(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(`SELECT\s+mz_sources.id`).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name", "schema_name", "database_name", "type", "size", "envelope_type", "connection_name", "cluster_name", "owner_name", "comment"}).
+				AddRow("u1", "source", "public", "materialize", "postgres", "xsmall", nil, "pg_conn", nil, "mz_system", nil),
+		)
+		mock.ExpectQuery(`SHOW CREATE SOURCE "materialize"."public"."source";`).WillReturnRows(
+			sqlmock.NewRows([]string{"name", "create_sql"}).AddRow("materialize.public.source", `CREATE SOURCE "materialize"."public"."source" FROM POSTGRES CONNECTION "materialize"."public"."pg_conn" (PUBLICATION = 'mz_source') FOR TABLES ("schema1"."table_1" AS "materialize"."public"."s1_table_1", "My, Table" AS "my_table") WITH (SIZE = 'xsmall')`),
+		)
+
+		diff := ImportPlan(t, Source(), "materialize.public.source", map[string]interface{}{
+			"name":                "source",
+			"size":                "xsmall",
+			"connection_type":     "postgres",
+			"postgres_connection": "pg_conn",
+			"publication":         "mz_source",
+			"tables": map[string]interface{}{
+				"schema1.table_1": "s1_table_1",
+				"My, Table":       "my_table",
+			},
+			"ownership_role": "mz_system",
+		}, testMeta(db))
+		r.True(diff.Empty(), "unexpected diff: %v", diff)
+	})
 }
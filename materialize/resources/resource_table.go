@@ -0,0 +1,399 @@
+package resources
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func Table() *schema.Resource {
+	return importDefaults(&schema.Resource{
+		Description: "A table persists durable data that can be written to, updated and seamlessly joined with other tables, views or sources.",
+
+		CreateContext: resourceTableCreate,
+		ReadContext:   resourceTableRead,
+		UpdateContext: resourceTableUpdate,
+		DeleteContext: resourceTableDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importQualifiedName(resourceTableRead, "database_name", "schema_name", "name"),
+		},
+
+		Timeouts: defaultTimeouts(),
+
+		CustomizeDiff: defaultDatabaseName,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "The identifier for the table.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"schema_name": {
+				Description: "The identifier for the table schema.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "public",
+			},
+			"database_name": {
+				Description: "The identifier for the table database. Defaults to the database of the provider configuration.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"column": {
+				Description: "Column of the table.",
+				Type:        schema.TypeList,
+				Required:    true,
+				ForceNew:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Description: "The name of the column to be created in the table.",
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+						},
+						"type": {
+							Description:      "The data type of the column.",
+							Type:             schema.TypeString,
+							Required:         true,
+							ForceNew:         true,
+							DiffSuppressFunc: diffSuppressColumnType,
+						},
+						"nullable": {
+							Description: "Whether the column may contain NULL values.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							ForceNew:    true,
+							Default:     true,
+						},
+						"default": {
+							Description: "A default value to use for the column in an INSERT statement if an explicit value is not provided.",
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+						},
+						"comment": {
+							Description: "A comment describing the column.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+					},
+				},
+			},
+		},
+	})
+}
+
+// Alternate spellings of the types reported by mz_columns
+var columnTypeAliases = map[string]string{
+	"bool":    "boolean",
+	"int":     "integer",
+	"int4":    "integer",
+	"int8":    "bigint",
+	"int2":    "smallint",
+	"float":   "double precision",
+	"float8":  "double precision",
+	"float4":  "real",
+	"decimal": "numeric",
+	"varchar": "character varying",
+	"char":    "character",
+	"json":    "jsonb",
+}
+
+// Splits a type such as varchar(255) into its base type, with aliases
+// resolved, and its type modifier
+func splitColumnType(t string) (string, string) {
+	t = strings.ToLower(strings.TrimSpace(t))
+
+	var modifier string
+	if i := strings.Index(t, "("); i >= 0 {
+		t, modifier = strings.TrimSpace(t[:i]), strings.ReplaceAll(t[i:], " ", "")
+	}
+
+	if a, ok := columnTypeAliases[t]; ok {
+		t = a
+	}
+	return t, modifier
+}
+
+func normalizeColumnType(t string) string {
+	base, modifier := splitColumnType(t)
+	return base + modifier
+}
+
+// mz_columns reports types without their modifier, so a modifier is only
+// compared when both types have one
+func diffSuppressColumnType(k, old, new string, d *schema.ResourceData) bool {
+	oldBase, oldModifier := splitColumnType(old)
+	newBase, newModifier := splitColumnType(new)
+	if oldBase != newBase {
+		return false
+	}
+	return oldModifier == "" || newModifier == "" || oldModifier == newModifier
+}
+
+type TableColumn struct {
+	colName    string
+	colType    string
+	nullable   bool
+	colDefault string
+	comment    string
+}
+
+type TableBuilder struct {
+	tableName    string
+	schemaName   string
+	databaseName string
+	columns      []TableColumn
+}
+
+func newTableBuilder(tableName, schemaName, databaseName string) *TableBuilder {
+	return &TableBuilder{
+		tableName:    tableName,
+		schemaName:   schemaName,
+		databaseName: databaseName,
+	}
+}
+
+func (b *TableBuilder) Columns(c []TableColumn) *TableBuilder {
+	b.columns = c
+	return b
+}
+
+func (b *TableBuilder) Create() string {
+	var columns []string
+	for _, c := range b.columns {
+		s := strings.Builder{}
+		s.WriteString(fmt.Sprintf(`%s %s`, quoteIdentifier(c.colName), c.colType))
+
+		if !c.nullable {
+			s.WriteString(` NOT NULL`)
+		}
+
+		if c.colDefault != "" {
+			s.WriteString(fmt.Sprintf(` DEFAULT %s`, c.colDefault))
+		}
+
+		columns = append(columns, s.String())
+	}
+
+	return fmt.Sprintf(`CREATE TABLE %s (%s);`, qualifiedName(b.databaseName, b.schemaName, b.tableName), strings.Join(columns[:], ", "))
+}
+
+func (b *TableBuilder) Read() (string, []interface{}) {
+	return `
+		SELECT
+			mz_tables.id,
+			mz_tables.name,
+			mz_schemas.name,
+			mz_databases.name
+		FROM mz_tables
+		JOIN mz_schemas
+			ON mz_tables.schema_id = mz_schemas.id
+		JOIN mz_databases
+			ON mz_schemas.database_id = mz_databases.id
+		WHERE mz_tables.name = $1
+		AND mz_schemas.name = $2
+		AND mz_databases.name = $3;
+	`, []interface{}{b.tableName, b.schemaName, b.databaseName}
+}
+
+func (b *TableBuilder) ReadColumns(tableId string) (string, []interface{}) {
+	return `
+		SELECT mz_columns.name, mz_columns.type, mz_columns.nullable, mz_comments.comment
+		FROM mz_columns
+		LEFT JOIN mz_internal.mz_comments
+			ON mz_columns.id = mz_comments.id
+			AND mz_columns.position = mz_comments.object_sub_id
+		WHERE mz_columns.id = $1
+		ORDER BY mz_columns.position;
+	`, []interface{}{tableId}
+}
+
+func (b *TableBuilder) ColumnComment(colName, comment string) string {
+	if comment == "" {
+		return fmt.Sprintf(`COMMENT ON COLUMN %s IS NULL;`, qualifiedName(b.databaseName, b.schemaName, b.tableName, colName))
+	}
+	return fmt.Sprintf(`COMMENT ON COLUMN %s IS %s;`, qualifiedName(b.databaseName, b.schemaName, b.tableName, colName), quoteString(comment))
+}
+
+func (b *TableBuilder) Rename(newName string) string {
+	return fmt.Sprintf(`ALTER TABLE %s RENAME TO %s;`, qualifiedName(b.databaseName, b.schemaName, b.tableName), quoteIdentifier(newName))
+}
+
+func (b *TableBuilder) Drop() string {
+	return fmt.Sprintf(`DROP TABLE %s;`, qualifiedName(b.databaseName, b.schemaName, b.tableName))
+}
+
+func resourceTableRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	conn := meta.(*ProviderMeta)
+	tableName := d.Get("name").(string)
+	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
+
+	builder := newTableBuilder(tableName, schemaName, databaseName)
+	q, args := builder.Read()
+
+	var id, name, schema, database string
+	if err := queryRow(ctx, conn, q, args, &id, &name, &schema, &database); err == sql.ErrNoRows {
+		log.Printf("[WARN] table (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return diags
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id)
+	d.Set("name", name)
+	d.Set("schema_name", schema)
+	d.Set("database_name", database)
+
+	q, args = builder.ReadColumns(id)
+	rows, err := QueryRows(ctx, conn, q, args)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer rows.Close()
+
+	// The catalog does not expose defaults or type modifiers in the configured
+	// form, so keep the configured default and type for columns at the same
+	// position
+	configured := d.Get("column").([]interface{})
+
+	var columns []map[string]interface{}
+	for rows.Next() {
+		var colName, colType string
+		var nullable bool
+		var comment sql.NullString
+		if err := rows.Scan(&colName, &colType, &nullable, &comment); err != nil {
+			return diag.FromErr(err)
+		}
+
+		column := map[string]interface{}{
+			"name":     colName,
+			"type":     colType,
+			"nullable": nullable,
+			"comment":  comment.String,
+		}
+
+		if i := len(columns); i < len(configured) {
+			c := configured[i].(map[string]interface{})
+			column["default"] = c["default"]
+
+			if t := c["type"].(string); diffSuppressColumnType("", colType, t, d) {
+				column["type"] = t
+			}
+		}
+
+		columns = append(columns, column)
+	}
+	d.Set("column", columns)
+
+	return diags
+}
+
+func resourceTableCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*ProviderMeta)
+
+	tableName := d.Get("name").(string)
+	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
+
+	builder := newTableBuilder(tableName, schemaName, databaseName)
+
+	if v, ok := d.GetOk("column"); ok {
+		var columns []TableColumn
+		for _, c := range v.([]interface{}) {
+			column := c.(map[string]interface{})
+			columns = append(columns, TableColumn{
+				colName:    column["name"].(string),
+				colType:    column["type"].(string),
+				nullable:   column["nullable"].(bool),
+				colDefault: column["default"].(string),
+				comment:    column["comment"].(string),
+			})
+		}
+		builder.Columns(columns)
+	}
+
+	q := builder.Create()
+
+	if diags := ExecResource(ctx, conn, q); diags.HasError() {
+		return diags
+	}
+
+	for _, c := range builder.columns {
+		if c.comment != "" {
+			if diags := ExecResource(ctx, conn, builder.ColumnComment(c.colName, c.comment)); diags.HasError() {
+				return diags
+			}
+		}
+	}
+
+	return resourceTableRead(ctx, d, meta)
+}
+
+func resourceTableUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*ProviderMeta)
+	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
+
+	if d.HasChange("name") {
+		oldName, newName := d.GetChange("name")
+
+		builder := newTableBuilder(oldName.(string), schemaName, databaseName)
+		q := builder.Rename(newName.(string))
+
+		if diags := ExecResource(ctx, conn, q); diags.HasError() {
+			return diags
+		}
+	}
+
+	// Column comments are the only column attribute altered in place
+	if d.HasChange("column") {
+		tableName := d.Get("name").(string)
+		oldColumns, newColumns := d.GetChange("column")
+
+		builder := newTableBuilder(tableName, schemaName, databaseName)
+
+		previous := oldColumns.([]interface{})
+		for i, c := range newColumns.([]interface{}) {
+			column := c.(map[string]interface{})
+			comment := column["comment"].(string)
+
+			if i < len(previous) && previous[i].(map[string]interface{})["comment"].(string) == comment {
+				continue
+			}
+
+			q := builder.ColumnComment(column["name"].(string), comment)
+			if diags := ExecResource(ctx, conn, q); diags.HasError() {
+				return diags
+			}
+		}
+	}
+
+	return resourceTableRead(ctx, d, meta)
+}
+
+func resourceTableDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*ProviderMeta)
+	tableName := d.Get("name").(string)
+	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
+
+	builder := newTableBuilder(tableName, schemaName, databaseName)
+	q := builder.Drop()
+
+	return ExecResource(ctx, conn, q)
+}
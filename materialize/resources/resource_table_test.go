@@ -0,0 +1,152 @@
+package resources
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceTableCreate(t *testing.T) {
+	r := require.New(t)
+	b := newTableBuilder("table", "schema", "database")
+	b.Columns([]TableColumn{
+		{
+			colName:  "column_1",
+			colType:  "int",
+			nullable: true,
+		},
+		{
+			colName:  "column_2",
+			colType:  "text",
+			nullable: false,
+		},
+		{
+			colName:    "column_3",
+			colType:    "text",
+			nullable:   false,
+			colDefault: "'default'",
+		},
+	})
+	r.Equal(`CREATE TABLE "database"."schema"."table" ("column_1" int, "column_2" text NOT NULL, "column_3" text NOT NULL DEFAULT 'default');`, b.Create())
+}
+
+func TestResourceTableRead(t *testing.T) {
+	r := require.New(t)
+	b := newTableBuilder("table", "schema", "database")
+	q, args := b.Read()
+	r.Equal(`
+		SELECT
+			mz_tables.id,
+			mz_tables.name,
+			mz_schemas.name,
+			mz_databases.name
+		FROM mz_tables
+		JOIN mz_schemas
+			ON mz_tables.schema_id = mz_schemas.id
+		JOIN mz_databases
+			ON mz_schemas.database_id = mz_databases.id
+		WHERE mz_tables.name = $1
+		AND mz_schemas.name = $2
+		AND mz_databases.name = $3;
+	`, q)
+	r.Equal([]interface{}{"table", "schema", "database"}, args)
+}
+
+func TestResourceTableReadColumns(t *testing.T) {
+	r := require.New(t)
+	b := newTableBuilder("table", "schema", "database")
+	q, args := b.ReadColumns("u1")
+	r.Equal(`
+		SELECT mz_columns.name, mz_columns.type, mz_columns.nullable, mz_comments.comment
+		FROM mz_columns
+		LEFT JOIN mz_internal.mz_comments
+			ON mz_columns.id = mz_comments.id
+			AND mz_columns.position = mz_comments.object_sub_id
+		WHERE mz_columns.id = $1
+		ORDER BY mz_columns.position;
+	`, q)
+	r.Equal([]interface{}{"u1"}, args)
+}
+
+func TestResourceTableColumnComment(t *testing.T) {
+	r := require.New(t)
+	b := newTableBuilder("table", "schema", "database")
+	r.Equal(`COMMENT ON COLUMN "database"."schema"."table"."column_1" IS 'A comment';`, b.ColumnComment("column_1", "A comment"))
+	r.Equal(`COMMENT ON COLUMN "database"."schema"."table"."column_1" IS NULL;`, b.ColumnComment("column_1", ""))
+}
+
+func TestResourceTableCreateUnusualNames(t *testing.T) {
+	r := require.New(t)
+	b := newTableBuilder("My-Table", "Schema.With.Dots", "database")
+	b.Columns([]TableColumn{
+		{
+			colName:  `Column "1"`,
+			colType:  "text",
+			nullable: true,
+		},
+	})
+	r.Equal(`CREATE TABLE "database"."Schema.With.Dots"."My-Table" ("Column ""1""" text);`, b.Create())
+	r.Equal(`COMMENT ON COLUMN "database"."Schema.With.Dots"."My-Table"."Column ""1""" IS 'Don''t panic';`, b.ColumnComment(`Column "1"`, "Don't panic"))
+}
+
+func TestResourceTableRename(t *testing.T) {
+	r := require.New(t)
+	b := newTableBuilder("table", "schema", "database")
+	r.Equal(`ALTER TABLE "database"."schema"."table" RENAME TO "new_table";`, b.Rename("new_table"))
+}
+
+func TestResourceTableDrop(t *testing.T) {
+	r := require.New(t)
+	b := newTableBuilder("table", "schema", "database")
+	r.Equal(`DROP TABLE "database"."schema"."table";`, b.Drop())
+}
+
+func TestNormalizeColumnType(t *testing.T) {
+	r := require.New(t)
+	r.Equal("integer", normalizeColumnType("INT"))
+	r.Equal("character varying", normalizeColumnType("varchar"))
+	r.Equal("text", normalizeColumnType("text"))
+	r.Equal("character varying(255)", normalizeColumnType("VARCHAR(255)"))
+	r.Equal("numeric(10,2)", normalizeColumnType("decimal(10, 2)"))
+}
+
+func TestDiffSuppressColumnType(t *testing.T) {
+	r := require.New(t)
+	r.True(diffSuppressColumnType("type", "character varying", "varchar(255)", nil))
+	r.True(diffSuppressColumnType("type", "numeric", "numeric(10,2)", nil))
+	r.True(diffSuppressColumnType("type", "numeric(10, 2)", "decimal(10,2)", nil))
+	r.False(diffSuppressColumnType("type", "varchar(100)", "varchar(255)", nil))
+	r.False(diffSuppressColumnType("type", "text", "varchar(255)", nil))
+}
+
+func TestResourceTableReadKeepsTypeModifier(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(`SELECT\s+mz_tables.id`).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name", "schema", "database"}).AddRow("u1", "table", "public", "materialize"),
+		)
+		mock.ExpectQuery(`SELECT mz_columns.name`).WillReturnRows(
+			sqlmock.NewRows([]string{"name", "type", "nullable", "comment"}).
+				AddRow("code", "character varying", false, nil).
+				AddRow("amount", "numeric", true, nil),
+		)
+
+		d := schema.TestResourceDataRaw(t, Table().Schema, map[string]interface{}{
+			"name": "table",
+			"column": []interface{}{
+				map[string]interface{}{"name": "code", "type": "varchar(255)", "nullable": false},
+				map[string]interface{}{"name": "amount", "type": "text"},
+			},
+		})
+
+		diags := resourceTableRead(context.TODO(), d, testMeta(db))
+		r.False(diags.HasError())
+		r.Equal("varchar(255)", d.Get("column.0.type"))
+		r.Equal("numeric", d.Get("column.1.type"))
+	})
+}
@@ -0,0 +1,207 @@
+package resources
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func View() *schema.Resource {
+	return importDefaults(&schema.Resource{
+		Description: "A non-materialized view, which provides an alias for the specified SELECT statement.",
+
+		CreateContext: resourceViewCreate,
+		ReadContext:   resourceViewRead,
+		UpdateContext: resourceViewUpdate,
+		DeleteContext: resourceViewDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importQualifiedName(resourceViewRead, "database_name", "schema_name", "name"),
+		},
+
+		Timeouts: defaultTimeouts(),
+
+		CustomizeDiff: defaultDatabaseName,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "The identifier for the view.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"schema_name": {
+				Description: "The identifier for the view schema.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "public",
+			},
+			"database_name": {
+				Description: "The identifier for the view database. Defaults to the database of the provider configuration.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"statement": {
+				Description:      "The SQL statement to create the view.",
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: suppressStatementDiff,
+				ForceNew:         true,
+			},
+			"definition": {
+				Description: "The view definition as stored in the catalog.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	})
+}
+
+type ViewBuilder struct {
+	viewName     string
+	schemaName   string
+	databaseName string
+	statement    string
+}
+
+func newViewBuilder(viewName, schemaName, databaseName string) *ViewBuilder {
+	return &ViewBuilder{
+		viewName:     viewName,
+		schemaName:   schemaName,
+		databaseName: databaseName,
+	}
+}
+
+func (b *ViewBuilder) Statement(s string) *ViewBuilder {
+	// Statements commonly end with a semicolon, which would terminate the DDL early
+	b.statement = strings.TrimSuffix(strings.TrimSpace(s), ";")
+	return b
+}
+
+func (b *ViewBuilder) Create() string {
+	return fmt.Sprintf(`CREATE VIEW %s AS %s;`, qualifiedName(b.databaseName, b.schemaName, b.viewName), b.statement)
+}
+
+func (b *ViewBuilder) Read() (string, []interface{}) {
+	return `
+		SELECT
+			mz_views.id,
+			mz_views.name,
+			mz_schemas.name,
+			mz_databases.name,
+			mz_views.definition
+		FROM mz_views
+		JOIN mz_schemas
+			ON mz_views.schema_id = mz_schemas.id
+		JOIN mz_databases
+			ON mz_schemas.database_id = mz_databases.id
+		WHERE mz_views.name = $1
+		AND mz_schemas.name = $2
+		AND mz_databases.name = $3;
+	`, []interface{}{b.viewName, b.schemaName, b.databaseName}
+}
+
+func (b *ViewBuilder) Rename(newName string) string {
+	return fmt.Sprintf(`ALTER VIEW %s RENAME TO %s;`, qualifiedName(b.databaseName, b.schemaName, b.viewName), quoteIdentifier(newName))
+}
+
+func (b *ViewBuilder) Drop() string {
+	return fmt.Sprintf(`DROP VIEW %s;`, qualifiedName(b.databaseName, b.schemaName, b.viewName))
+}
+
+func resourceViewRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	conn := meta.(*ProviderMeta)
+	viewName := d.Get("name").(string)
+	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
+
+	builder := newViewBuilder(viewName, schemaName, databaseName)
+	q, args := builder.Read()
+
+	var id, name, schema, database, definition string
+	if err := queryRow(ctx, conn, q, args, &id, &name, &schema, &database, &definition); err == sql.ErrNoRows {
+		log.Printf("[WARN] view (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return diags
+	} else if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id)
+	d.Set("name", name)
+	d.Set("schema_name", schema)
+	d.Set("database_name", database)
+
+	// The catalog stores a normalized definition, so compare against the
+	// definition recorded at creation rather than the configured statement
+	if v, ok := d.GetOk("definition"); ok && v.(string) != definition {
+		d.Set("statement", definition)
+	} else if _, ok := d.GetOk("statement"); !ok {
+		// Imported views take their statement from the catalog
+		d.Set("statement", definition)
+	}
+	d.Set("definition", definition)
+
+	return diags
+}
+
+func resourceViewCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*ProviderMeta)
+
+	viewName := d.Get("name").(string)
+	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
+
+	builder := newViewBuilder(viewName, schemaName, databaseName)
+
+	if v, ok := d.GetOk("statement"); ok {
+		builder.Statement(v.(string))
+	}
+
+	q := builder.Create()
+
+	if diags := ExecResource(ctx, conn, q); diags.HasError() {
+		return diags
+	}
+	return resourceViewRead(ctx, d, meta)
+}
+
+func resourceViewUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*ProviderMeta)
+	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
+
+	if d.HasChange("name") {
+		oldName, newName := d.GetChange("name")
+
+		builder := newViewBuilder(oldName.(string), schemaName, databaseName)
+		q := builder.Rename(newName.(string))
+
+		if diags := ExecResource(ctx, conn, q); diags.HasError() {
+			return diags
+		}
+	}
+
+	return resourceViewRead(ctx, d, meta)
+}
+
+func resourceViewDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*ProviderMeta)
+	viewName := d.Get("name").(string)
+	schemaName := d.Get("schema_name").(string)
+	databaseName := d.Get("database_name").(string)
+
+	builder := newViewBuilder(viewName, schemaName, databaseName)
+	q := builder.Drop()
+
+	return ExecResource(ctx, conn, q)
+}
@@ -0,0 +1,71 @@
+package resources
+
+import (
+	"database/sql"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceViewCreate(t *testing.T) {
+	r := require.New(t)
+	b := newViewBuilder("view", "schema", "database")
+	b.Statement(`SELECT * FROM schema.table`)
+	r.Equal(`CREATE VIEW "database"."schema"."view" AS SELECT * FROM schema.table;`, b.Create())
+
+	b.Statement("SELECT * FROM schema.table;\n")
+	r.Equal(`CREATE VIEW "database"."schema"."view" AS SELECT * FROM schema.table;`, b.Create())
+}
+
+func TestResourceViewRead(t *testing.T) {
+	r := require.New(t)
+	b := newViewBuilder("view", "schema", "database")
+	q, args := b.Read()
+	r.Equal(`
+		SELECT
+			mz_views.id,
+			mz_views.name,
+			mz_schemas.name,
+			mz_databases.name,
+			mz_views.definition
+		FROM mz_views
+		JOIN mz_schemas
+			ON mz_views.schema_id = mz_schemas.id
+		JOIN mz_databases
+			ON mz_schemas.database_id = mz_databases.id
+		WHERE mz_views.name = $1
+		AND mz_schemas.name = $2
+		AND mz_databases.name = $3;
+	`, q)
+	r.Equal([]interface{}{"view", "schema", "database"}, args)
+}
+
+func TestResourceViewRename(t *testing.T) {
+	r := require.New(t)
+	b := newViewBuilder("view", "schema", "database")
+	r.Equal(`ALTER VIEW "database"."schema"."view" RENAME TO "new_view";`, b.Rename("new_view"))
+}
+
+func TestResourceViewDrop(t *testing.T) {
+	r := require.New(t)
+	b := newViewBuilder("view", "schema", "database")
+	r.Equal(`DROP VIEW "database"."schema"."view";`, b.Drop())
+}
+
+func TestResourceViewImport(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(`SELECT\s+mz_views.id`).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name", "schema", "database", "definition"}).
+				AddRow("u1", "view", "public", "materialize", `SELECT "id", "name" FROM "materialize"."public"."table";`),
+		)
+
+		diff := ImportPlan(t, View(), "materialize.public.view", map[string]interface{}{
+			"name":      "view",
+			"statement": "SELECT id, name FROM table",
+		}, testMeta(db))
+		r.True(diff.Empty(), "unexpected diff: %v", diff)
+	})
+}
@@ -0,0 +1,162 @@
+package resources
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Settings for retrying statements and catalog reads that fail with a
+// transient error, configured by the provider
+type RetryConfig struct {
+	MaxRetries int
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// Errors that are expected to succeed when the statement is run again, such
+// as dropped connections and serialization failures from catalog contention
+func isRetryable(err error) bool {
+	// context.DeadlineExceeded implements net.Error but means the resource
+	// timeout has passed
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code.Class() {
+		case "08", "40":
+			// connection_exception, transaction_rollback
+			return true
+		}
+
+		switch pqErr.Code {
+		case "53300", "57P01", "57P02", "57P03":
+			// too_many_connections, admin_shutdown, crash_shutdown, cannot_connect_now
+			return true
+		}
+		return false
+	}
+
+	if errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// Errors that leave it unknown whether the server applied the statement, as
+// the connection was lost after the statement may have been sent. Failures to
+// connect and errors reported by the server mean it was not applied.
+func isAmbiguous(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case "08006", "57P01", "57P02":
+			// connection_failure, admin_shutdown, crash_shutdown
+			return true
+		}
+		return false
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" || errors.Is(err, syscall.ECONNREFUSED) {
+		return false
+	}
+
+	return errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.As(err, new(net.Error))
+}
+
+// Errors a statement reports when an earlier attempt of it was applied before
+// its connection was lost, e.g. the object of a CREATE already exists
+func isAlreadyApplied(q string, err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+
+	statement := strings.ToUpper(strings.TrimSpace(q))
+	switch {
+	case strings.HasPrefix(statement, "CREATE "):
+		switch pqErr.Code {
+		case "42710", "42P04", "42P06", "42P07":
+			// duplicate_object, duplicate_database, duplicate_schema, duplicate_table
+			return true
+		}
+	case strings.HasPrefix(statement, "DROP "):
+		switch pqErr.Code {
+		case "42704", "3D000", "3F000", "42P01":
+			// undefined_object, invalid_catalog_name, invalid_schema_name, undefined_table
+			return true
+		}
+	}
+	return false
+}
+
+// Runs f until it succeeds, fails with an error that is not retryable or the
+// retries are used up, doubling the backoff after each attempt. Retrying stops
+// early when the next attempt would start after the context deadline, so the
+// resource timeout bounds the total time spent.
+func withRetry(ctx context.Context, c RetryConfig, f func() error) error {
+	backoff := c.MinBackoff
+	for attempt := 0; ; attempt++ {
+		err := f()
+		if err == nil || attempt >= c.MaxRetries || !isRetryable(err) {
+			return err
+		}
+
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < backoff {
+			return err
+		}
+
+		log.Printf("[WARN] retrying in %s after transient error (attempt %d of %d): %s", backoff, attempt+1, c.MaxRetries, err)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > c.MaxBackoff {
+			backoff = c.MaxBackoff
+		}
+	}
+}
+
+// Reads a single catalog row into dest, retrying transient errors.
+// sql.ErrNoRows is returned unchanged for missing objects.
+func queryRow(ctx context.Context, conn *ProviderMeta, q string, args []interface{}, dest ...interface{}) error {
+	return withRetry(ctx, conn.Retry, func() error {
+		return conn.DB.QueryRowContext(ctx, q, args...).Scan(dest...)
+	})
+}
+
+// Runs a catalog query returning multiple rows, retrying transient errors
+// until the query starts returning rows
+func QueryRows(ctx context.Context, conn *ProviderMeta, q string, args []interface{}) (*sql.Rows, error) {
+	var rows *sql.Rows
+	err := withRetry(ctx, conn.Retry, func() error {
+		var err error
+		rows, err = conn.DB.QueryContext(ctx, q, args...)
+		return err
+	})
+	return rows, err
+}
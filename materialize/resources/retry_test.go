@@ -0,0 +1,192 @@
+package resources
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRetryable(t *testing.T) {
+	r := require.New(t)
+
+	connReset := &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+
+	r.True(isRetryable(&pq.Error{Code: "40001"}))
+	r.True(isRetryable(&pq.Error{Code: "40P01"}))
+	r.True(isRetryable(&pq.Error{Code: "08006"}))
+	r.True(isRetryable(&pq.Error{Code: "57P01"}))
+	r.True(isRetryable(&pq.Error{Code: "53300"}))
+	r.True(isRetryable(connReset))
+	r.True(isRetryable(fmt.Errorf("query: %w", syscall.ECONNREFUSED)))
+
+	r.False(isRetryable(&pq.Error{Code: "42710"}))
+	r.False(isRetryable(&pq.Error{Code: "42601"}))
+	r.False(isRetryable(&pq.Error{Code: "57014"}))
+	r.False(isRetryable(sql.ErrNoRows))
+	r.False(isRetryable(context.DeadlineExceeded))
+	r.False(isRetryable(errors.New("unexpected")))
+}
+
+func TestWithRetry(t *testing.T) {
+	r := require.New(t)
+	c := RetryConfig{MaxRetries: 3, MinBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+
+	attempts := 0
+	err := withRetry(context.TODO(), c, func() error {
+		attempts++
+		if attempts < 3 {
+			return &pq.Error{Code: "40001"}
+		}
+		return nil
+	})
+	r.NoError(err)
+	r.Equal(3, attempts)
+}
+
+func TestWithRetryFatal(t *testing.T) {
+	r := require.New(t)
+	c := RetryConfig{MaxRetries: 3, MinBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+
+	attempts := 0
+	err := withRetry(context.TODO(), c, func() error {
+		attempts++
+		return &pq.Error{Code: "42710"}
+	})
+	r.Error(err)
+	r.Equal(1, attempts)
+}
+
+func TestWithRetryExhausted(t *testing.T) {
+	r := require.New(t)
+	c := RetryConfig{MaxRetries: 2, MinBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+
+	attempts := 0
+	err := withRetry(context.TODO(), c, func() error {
+		attempts++
+		return &pq.Error{Code: "40001"}
+	})
+	r.Error(err)
+	r.Equal(3, attempts)
+}
+
+func TestWithRetryDeadline(t *testing.T) {
+	r := require.New(t)
+	c := RetryConfig{MaxRetries: 3, MinBackoff: time.Minute, MaxBackoff: time.Minute}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	attempts := 0
+	err := withRetry(ctx, c, func() error {
+		attempts++
+		return &pq.Error{Code: "40001"}
+	})
+	r.Error(err)
+	r.Equal(1, attempts)
+}
+
+func TestExecResourceRetry(t *testing.T) {
+	r := require.New(t)
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.MatchExpectationsInOrder(true)
+		mock.ExpectExec(`CREATE DATABASE`).WillReturnError(&pq.Error{Code: "40001", Message: "serialization failure"})
+		mock.ExpectExec(`CREATE DATABASE`).WillReturnResult(sqlmock.NewResult(1, 1))
+
+		diags := ExecResource(context.TODO(), testMeta(db), `CREATE DATABASE "database";`)
+		r.False(diags.HasError())
+	})
+}
+
+func TestQueryRowRetry(t *testing.T) {
+	r := require.New(t)
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.MatchExpectationsInOrder(true)
+		mock.ExpectQuery(`SELECT id`).WillReturnError(&net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET})
+		mock.ExpectQuery(`SELECT id`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("u1"))
+
+		var id string
+		err := queryRow(context.TODO(), testMeta(db), `SELECT id FROM mz_databases WHERE name = $1;`, []interface{}{"database"}, &id)
+		r.NoError(err)
+		r.Equal("u1", id)
+	})
+}
+
+func TestIsAmbiguous(t *testing.T) {
+	r := require.New(t)
+
+	r.True(isAmbiguous(&net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}))
+	r.True(isAmbiguous(io.ErrUnexpectedEOF))
+	r.True(isAmbiguous(&pq.Error{Code: "57P01"}))
+
+	r.False(isAmbiguous(&net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}))
+	r.False(isAmbiguous(syscall.ECONNREFUSED))
+	r.False(isAmbiguous(driver.ErrBadConn))
+	r.False(isAmbiguous(&pq.Error{Code: "40001"}))
+	r.False(isAmbiguous(&pq.Error{Code: "53300"}))
+}
+
+func TestIsAlreadyApplied(t *testing.T) {
+	r := require.New(t)
+
+	r.True(isAlreadyApplied(`CREATE SECRET "database"."schema"."secret" AS 'password';`, &pq.Error{Code: "42710"}))
+	r.True(isAlreadyApplied(`CREATE DATABASE "database";`, &pq.Error{Code: "42P04"}))
+	r.True(isAlreadyApplied(`DROP CLUSTER "cluster";`, &pq.Error{Code: "42704"}))
+
+	r.False(isAlreadyApplied(`ALTER CLUSTER "cluster" RENAME TO "other";`, &pq.Error{Code: "42710"}))
+	r.False(isAlreadyApplied(`DROP CLUSTER "cluster";`, &pq.Error{Code: "42710"}))
+	r.False(isAlreadyApplied(`CREATE DATABASE "database";`, &pq.Error{Code: "40001"}))
+}
+
+func TestExecResourceAppliedBeforeConnectionLost(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.MatchExpectationsInOrder(true)
+		mock.ExpectExec(`CREATE DATABASE`).WillReturnError(io.ErrUnexpectedEOF)
+		mock.ExpectExec(`CREATE DATABASE`).WillReturnError(&pq.Error{Code: "42P04", Message: "database 'database' already exists"})
+
+		diags := ExecResource(context.TODO(), testMeta(db), `CREATE DATABASE "database";`)
+		r.False(diags.HasError())
+	})
+}
+
+func TestExecResourceExistsAfterServerError(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.MatchExpectationsInOrder(true)
+		mock.ExpectExec(`CREATE DATABASE`).WillReturnError(&pq.Error{Code: "40001", Message: "serialization failure"})
+		mock.ExpectExec(`CREATE DATABASE`).WillReturnError(&pq.Error{Code: "42P04", Message: "database 'database' already exists"})
+
+		// The first attempt was rejected, so the database belongs to someone else
+		diags := ExecResource(context.TODO(), testMeta(db), `CREATE DATABASE "database";`)
+		r.True(diags.HasError())
+		r.Contains(diags[0].Summary, "already exists")
+	})
+}
+
+func TestExecResourceRetryConfig(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.ExpectExec(`CREATE DATABASE`).WillReturnError(&pq.Error{Code: "40001", Message: "serialization failure"})
+
+		// Each provider configuration carries its own retry settings
+		meta := testMeta(db)
+		meta.Retry.MaxRetries = 0
+
+		diags := ExecResource(context.TODO(), meta, `CREATE DATABASE "database";`)
+		r.True(diags.HasError())
+	})
+}
@@ -1,10 +1,14 @@
 package resources
 
 import (
+	"context"
 	"database/sql"
 	"testing"
+	"time"
 
 	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/require"
 )
 
@@ -22,3 +26,43 @@ func WithMockDb(t *testing.T, f func(*sql.DB, sqlmock.Sqlmock)) {
 		t.Errorf("There were unfulfilled expectations: %s", err)
 	}
 }
+
+// Imports a resource by ID and plans the given configuration against the
+// imported state, so a clean import returns an empty diff
+func ImportPlan(t *testing.T, resource *schema.Resource, id string, config map[string]interface{}, meta interface{}) *terraform.InstanceDiff {
+	t.Helper()
+	r := require.New(t)
+
+	d := resource.Data(&terraform.InstanceState{ID: id})
+	states, err := resource.Importer.StateContext(context.TODO(), d, meta)
+	r.NoError(err)
+	r.Len(states, 1)
+
+	diff, err := resource.Diff(context.TODO(), states[0].State(), terraform.NewResourceConfigRaw(config), meta)
+	r.NoError(err)
+	return diff
+}
+
+// Builds the resource data for an update from the prior state attributes to
+// the given configuration
+func UpdateData(t *testing.T, resource *schema.Resource, id string, state map[string]string, config map[string]interface{}, meta interface{}) *schema.ResourceData {
+	t.Helper()
+	r := require.New(t)
+
+	s := &terraform.InstanceState{ID: id, Attributes: state}
+	diff, err := resource.Diff(context.TODO(), s, terraform.NewResourceConfigRaw(config), meta)
+	r.NoError(err)
+
+	d, err := schema.InternalMap(resource.Schema).Data(s, diff)
+	r.NoError(err)
+	return d
+}
+
+// The provider meta passed to resources under test
+func testMeta(db *sql.DB) *ProviderMeta {
+	return &ProviderMeta{
+		DB:       db,
+		Database: "materialize",
+		Retry:    RetryConfig{MaxRetries: 3, MinBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond},
+	}
+}
@@ -1,18 +1,454 @@
 package resources
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
 )
 
-func ExecResource(conn *sql.DB, queryStr string) diag.Diagnostics {
+// The value the provider passes to resources, the connection together with
+// the provider settings that resources fall back on
+type ProviderMeta struct {
+	DB       *sql.DB
+	Database string
+	Retry    RetryConfig
+}
+
+// Plans the database of the provider configuration for resources that do not
+// specify a database name
+func defaultDatabaseName(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Get("database_name").(string) == "" {
+		return d.SetNew("database_name", meta.(*ProviderMeta).Database)
+	}
+	return nil
+}
+
+// Timeouts for resources that only issue catalog reads and DDL statements
+func defaultTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(5 * time.Minute),
+		Read:   schema.DefaultTimeout(5 * time.Minute),
+		Update: schema.DefaultTimeout(5 * time.Minute),
+		Delete: schema.DefaultTimeout(5 * time.Minute),
+	}
+}
+
+func ExecResource(ctx context.Context, conn *ProviderMeta, queryStr string) diag.Diagnostics {
+	return execRedactedResource(ctx, conn, queryStr, queryStr)
+}
+
+// Executes a statement that contains sensitive values, reporting the
+// redacted form of the statement if it fails
+func execRedactedResource(ctx context.Context, conn *ProviderMeta, queryStr, redacted string) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	_, err := conn.Exec(queryStr)
+	// A statement interrupted by a lost connection may have been applied, in
+	// which case its retry fails because the object was already created or
+	// dropped
+	ambiguous := false
+	err := withRetry(ctx, conn.Retry, func() error {
+		_, err := conn.DB.ExecContext(ctx, queryStr)
+		if ambiguous && isAlreadyApplied(queryStr, err) {
+			log.Printf("[WARN] statement was applied before the connection was lost: %s", redacted)
+			return nil
+		}
+		ambiguous = err != nil && isAmbiguous(err)
+		return err
+	})
 	if err != nil {
-		return diag.FromErr(err)
+		return execDiagnostics(redacted, err)
 	}
 
 	return diags
 }
+
+func execDiagnostics(queryStr string, err error) diag.Diagnostics {
+	detail := strings.Builder{}
+	detail.WriteString(fmt.Sprintf("Statement: %s", queryStr))
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		detail.WriteString(fmt.Sprintf("\nCode: %s", pqErr.Code))
+
+		if pqErr.Detail != "" {
+			detail.WriteString(fmt.Sprintf("\nDetail: %s", pqErr.Detail))
+		}
+
+		if pqErr.Hint != "" {
+			detail.WriteString(fmt.Sprintf("\nHint: %s", pqErr.Hint))
+		}
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("error executing statement: %s", err),
+		Detail:   detail.String(),
+	}}
+}
+
+// Polls a status query returning the status and error of an object until it
+// reports the ready status. Failed objects stop the polling straight away,
+// any other status is retried until the timeout with the last reported error.
+func waitForReady(ctx context.Context, conn *ProviderMeta, timeout time.Duration, object, ready, q string, args ...interface{}) error {
+	return resource.RetryContext(ctx, timeout, func() *resource.RetryError {
+		var status string
+		var statusError sql.NullString
+		if err := queryRow(ctx, conn, q, args, &status, &statusError); err == sql.ErrNoRows {
+			log.Printf("[INFO] waiting for %s to report a status", object)
+			return resource.RetryableError(fmt.Errorf("%s has not reported a status", object))
+		} else if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		switch status {
+		case ready:
+			return nil
+		case "failed":
+			return resource.NonRetryableError(fmt.Errorf("%s failed: %s", object, statusError.String))
+		}
+
+		log.Printf("[INFO] waiting for %s to be %s, currently %s", object, ready, status)
+		if statusError.String != "" {
+			return resource.RetryableError(fmt.Errorf("%s is %s: %s", object, status, statusError.String))
+		}
+		return resource.RetryableError(fmt.Errorf("%s is %s", object, status))
+	})
+}
+
+// Wraps an identifier in double quotes so mixed case names and names
+// containing dots, hyphens or quotes are used verbatim
+func quoteIdentifier(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+// Quotes each part of a name and joins them, e.g. "database"."schema"."name"
+func qualifiedName(parts ...string) string {
+	var q []string
+	for _, p := range parts {
+		q = append(q, quoteIdentifier(p))
+	}
+	return strings.Join(q, ".")
+}
+
+// References to other objects may be given qualified as schema.name or
+// database.schema.name, so each part is quoted separately
+func quoteReference(s string) string {
+	return qualifiedName(strings.Split(s, ".")...)
+}
+
+// Wraps a string literal in single quotes, escaping embedded quotes
+func quoteString(s string) string {
+	return `'` + strings.ReplaceAll(s, `'`, `''`) + `'`
+}
+
+// Quotes each string literal and joins them into a comma separated list
+func quoteStrings(s []string) string {
+	var q []string
+	for _, e := range s {
+		q = append(q, quoteString(e))
+	}
+	return strings.Join(q, ", ")
+}
+
+// Reads the statement Materialize recorded for an object through SHOW CREATE,
+// with every name it references resolved and fully qualified
+func readCreateSql(ctx context.Context, conn *ProviderMeta, q string) (string, error) {
+	var name, createSql string
+	if err := queryRow(ctx, conn, q, nil, &name, &createSql); err != nil {
+		return "", err
+	}
+	return createSql, nil
+}
+
+// Blanks out the contents of string literals and quoted identifiers, keeping
+// the length of the statement, so clauses can be searched for without
+// matching the names of objects
+func maskQuoted(s string) string {
+	b := []byte(s)
+	var quote byte
+	for i, c := range b {
+		switch {
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+			b[i] = '_'
+		case c == '\'' || c == '"':
+			quote = c
+		}
+	}
+	return string(b)
+}
+
+const (
+	maskedString = `'[^']*'(?:'[^']*')*`
+	maskedName   = `"[^"]*"(?:"[^"]*")*(?:\."[^"]*"(?:"[^"]*")*)*`
+)
+
+// Returns the string literal or name following a clause of a recorded CREATE
+// statement, e.g. the topic of (TOPIC = 'events'), unquoted
+func createSqlValue(createSql, clause string) string {
+	re := regexp.MustCompile(`\b` + clause + `(?: = | )(?:SECRET )?(` + maskedString + `|` + maskedName + `)`)
+	m := re.FindStringSubmatchIndex(maskQuoted(createSql))
+	if m == nil {
+		return ""
+	}
+	return unquoteValue(createSql[m[2]:m[3]])
+}
+
+var formatClause = regexp.MustCompile(`\bFORMAT (.+?)(?: USING | ENVELOPE | INCLUDE | WITH \(| IN CLUSTER | EXPOSE |;|$)`)
+
+// Returns the format of a recorded CREATE SOURCE or CREATE SINK statement,
+// e.g. JSON for FORMAT JSON ENVELOPE DEBEZIUM
+func createSqlFormat(createSql string) string {
+	m := formatClause.FindStringSubmatchIndex(maskQuoted(createSql))
+	if m == nil {
+		return ""
+	}
+	return createSql[m[2]:m[3]]
+}
+
+// Unquotes a string literal or a quoted, possibly qualified, identifier
+func unquoteValue(v string) string {
+	switch {
+	case strings.HasPrefix(v, "'"):
+		return strings.ReplaceAll(strings.TrimSuffix(strings.TrimPrefix(v, "'"), "'"), "''", "'")
+	case strings.HasPrefix(v, `"`):
+		var parts []string
+		for _, p := range splitQualifiedName(v) {
+			parts = append(parts, strings.ReplaceAll(strings.TrimSuffix(strings.TrimPrefix(p, `"`), `"`), `""`, `"`))
+		}
+		return strings.Join(parts, ".")
+	}
+	return v
+}
+
+// Splits a qualified name on the dots between its quoted parts
+func splitQualifiedName(v string) []string {
+	var parts []string
+	masked := maskQuoted(v)
+	start := 0
+	for i := range masked {
+		if masked[i] == '.' {
+			parts = append(parts, v[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, v[start:])
+}
+
+// Splits a parenthesized list such as ('a', 'b') into its elements, leaving
+// commas inside quotes and nested parentheses alone
+func splitList(v string) []string {
+	v = strings.TrimSpace(v)
+	if !strings.HasPrefix(v, "(") || !strings.HasSuffix(v, ")") {
+		return nil
+	}
+	v = v[1 : len(v)-1]
+
+	var elements []string
+	masked := maskQuoted(v)
+	depth, start := 0, 0
+	for i := 0; i < len(masked); i++ {
+		switch masked[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				elements = append(elements, strings.TrimSpace(v[start:i]))
+				start = i + 1
+			}
+		}
+	}
+
+	if last := strings.TrimSpace(v[start:]); last != "" {
+		elements = append(elements, last)
+	}
+	return elements
+}
+
+// References to secrets and connections may be configured unqualified or
+// partially qualified, while the catalog reports database.schema.name
+func suppressQualifiedNameDiff(k, old, new string, d *schema.ResourceData) bool {
+	if old == "" || new == "" {
+		return old == new
+	}
+
+	o, n := strings.Split(old, "."), strings.Split(new, ".")
+	if len(n) > len(o) {
+		o, n = n, o
+	}
+
+	for i := 1; i <= len(n); i++ {
+		if n[len(n)-i] != o[len(o)-i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Keywords are validated case insensitively, while the catalog reports a
+// single case
+func suppressCaseDiff(k, old, new string, d *schema.ResourceData) bool {
+	return strings.EqualFold(old, new)
+}
+
+// Durations may be written in different units, e.g. 1s and 1000ms, while the
+// catalog reports a single form
+func suppressDurationDiff(k, old, new string, d *schema.ResourceData) bool {
+	o, oerr := time.ParseDuration(old)
+	n, nerr := time.ParseDuration(new)
+	if oerr != nil || nerr != nil {
+		return old == new
+	}
+	return o == n
+}
+
+var statementPunctuation = regexp.MustCompile(`\s*([(),])\s*`)
+
+// Normalizes a SELECT statement for comparison with the definition recorded
+// in the catalog, which quotes and qualifies every name. Case, whitespace and
+// identifier quotes are ignored outside of string literals, and names are
+// unqualified when they are in the given database and schema.
+func normalizeStatement(s, databaseName, schemaName string) string {
+	s = strings.TrimSuffix(strings.TrimSpace(s), ";")
+
+	n := strings.Builder{}
+	var inString, space bool
+	for _, c := range s {
+		switch {
+		case inString:
+			n.WriteRune(c)
+			inString = c != '\''
+		case c == '\'':
+			n.WriteRune(c)
+			inString = true
+		case c == '"':
+			continue
+		case unicode.IsSpace(c):
+			space = true
+			continue
+		default:
+			if space && n.Len() > 0 {
+				n.WriteRune(' ')
+			}
+			n.WriteRune(unicode.ToLower(c))
+		}
+		space = false
+	}
+
+	q := statementPunctuation.ReplaceAllString(n.String(), "$1")
+	for _, prefix := range []string{databaseName + "." + schemaName + ".", databaseName + ".", schemaName + "."} {
+		re := regexp.MustCompile(`(^|[^\w.])` + regexp.QuoteMeta(strings.ToLower(prefix)))
+		q = re.ReplaceAllString(q, "${1}")
+	}
+	return q
+}
+
+// The catalog records view definitions in a normalized form, so statements
+// are compared after normalizing both sides
+func suppressStatementDiff(k, old, new string, d *schema.ResourceData) bool {
+	databaseName := d.Get("database_name").(string)
+	schemaName := d.Get("schema_name").(string)
+	return normalizeStatement(old, databaseName, schemaName) == normalizeStatement(new, databaseName, schemaName)
+}
+
+func sliceOfStrings(v interface{}) []string {
+	var s []string
+	for _, e := range v.([]interface{}) {
+		s = append(s, e.(string))
+	}
+	return s
+}
+
+// Splits the qualified name of an import ID into its parts. Parts containing
+// dots are quoted as identifiers, e.g. "my.database".schema.name
+func splitImportName(id string) []string {
+	var parts []string
+	for _, p := range splitQualifiedName(id) {
+		parts = append(parts, unquoteValue(p))
+	}
+	return parts
+}
+
+// Imports a resource whose ID is a qualified name such as database.schema.name,
+// setting each part of the name to the matching attribute before reading
+func importQualifiedName(read schema.ReadContextFunc, attributes ...string) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		parts := splitImportName(d.Id())
+		if len(parts) != len(attributes) {
+			return nil, fmt.Errorf("unexpected format of ID (%s), expected %s", d.Id(), strings.Join(attributes, "."))
+		}
+
+		for i, a := range attributes {
+			if parts[i] == "" {
+				return nil, fmt.Errorf("unexpected format of ID (%s), %s is empty", d.Id(), a)
+			}
+			d.Set(a, parts[i])
+		}
+
+		return importRead(ctx, d, meta, read)
+	}
+}
+
+// Imported state only holds the attributes Read sets, so attributes that are
+// not stored in the catalog are given their schema defaults, as they would be
+// for a configuration that leaves them unset
+func importDefaults(r *schema.Resource) *schema.Resource {
+	importer := r.Importer.StateContext
+	r.Importer.StateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		states, err := importer(ctx, d, meta)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, s := range states {
+			attributes := s.State().Attributes
+			for k, v := range r.Schema {
+				if _, ok := attributes[k]; !ok && v.Default != nil {
+					s.Set(k, v.Default)
+				}
+			}
+		}
+		return states, nil
+	}
+	return r
+}
+
+// Joins the summary and detail of a diagnostic into a single message
+func diagnosticText(d diag.Diagnostic) string {
+	if d.Detail == "" {
+		return d.Summary
+	}
+	return d.Summary + "\n" + d.Detail
+}
+
+func importRead(ctx context.Context, d *schema.ResourceData, meta interface{}, read schema.ReadContextFunc) ([]*schema.ResourceData, error) {
+	id := d.Id()
+
+	if diags := read(ctx, d, meta); diags.HasError() {
+		for _, e := range diags {
+			if e.Severity == diag.Error {
+				return nil, fmt.Errorf("unable to read %s: %s", id, diagnosticText(e))
+			}
+		}
+	}
+
+	if d.Id() == "" {
+		return nil, fmt.Errorf("%s not found", id)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
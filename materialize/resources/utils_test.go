@@ -0,0 +1,283 @@
+package resources
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportQualifiedName(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		rows := sqlmock.NewRows([]string{"id", "name", "database", "owner", "comment"}).
+			AddRow("u1", "schema", "database", "role", nil)
+		mock.ExpectQuery(`SELECT mz_schemas.id`).WillReturnRows(rows)
+
+		d := schema.TestResourceDataRaw(t, Schema().Schema, map[string]interface{}{})
+		d.SetId("database.schema")
+
+		importer := importQualifiedName(resourceSchemaRead, "database_name", "name")
+		s, err := importer(context.TODO(), d, testMeta(db))
+		r.NoError(err)
+		r.Len(s, 1)
+
+		r.Equal("u1", d.Id())
+		r.Equal("schema", d.Get("name"))
+		r.Equal("database", d.Get("database_name"))
+		r.Equal("role", d.Get("ownership_role"))
+	})
+}
+
+func TestImportQualifiedNameQuoted(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		rows := sqlmock.NewRows([]string{"id", "name", "database", "owner", "comment"}).
+			AddRow("u1", "my.schema", "database", "role", nil)
+		mock.ExpectQuery(`SELECT mz_schemas.id`).WithArgs("my.schema", "database").WillReturnRows(rows)
+
+		d := schema.TestResourceDataRaw(t, Schema().Schema, map[string]interface{}{})
+		d.SetId(`database."my.schema"`)
+
+		importer := importQualifiedName(resourceSchemaRead, "database_name", "name")
+		_, err := importer(context.TODO(), d, testMeta(db))
+		r.NoError(err)
+		r.Equal("my.schema", d.Get("name"))
+		r.Equal("database", d.Get("database_name"))
+	})
+}
+
+func TestImportQualifiedNameReadError(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(`SELECT mz_schemas.id`).WillReturnError(&pq.Error{Code: "42501", Message: "permission denied for DATABASE \"database\""})
+
+		d := schema.TestResourceDataRaw(t, Schema().Schema, map[string]interface{}{})
+		d.SetId("database.schema")
+
+		importer := importQualifiedName(resourceSchemaRead, "database_name", "name")
+		_, err := importer(context.TODO(), d, testMeta(db))
+		r.Error(err)
+		r.Contains(err.Error(), "unable to read database.schema: ")
+		r.Contains(err.Error(), "permission denied")
+	})
+}
+
+func TestImportQualifiedNameInvalid(t *testing.T) {
+	r := require.New(t)
+
+	d := schema.TestResourceDataRaw(t, Schema().Schema, map[string]interface{}{})
+	d.SetId("schema")
+
+	importer := importQualifiedName(resourceSchemaRead, "database_name", "name")
+	_, err := importer(context.TODO(), d, nil)
+	r.EqualError(err, "unexpected format of ID (schema), expected database_name.name")
+}
+
+func TestResourceGrantImportInvalid(t *testing.T) {
+	r := require.New(t)
+
+	d := schema.TestResourceDataRaw(t, GrantTable().Schema, map[string]interface{}{})
+	d.SetId("role:SELECT:schema.table")
+
+	_, err := resourceGrantImport("TABLE")(context.TODO(), d, nil)
+	r.EqualError(err, "unexpected format of ID (role:SELECT:schema.table), expected role:privilege:database_name.schema_name.table_name")
+}
+
+func TestExecResourceError(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.ExpectExec(`CREATE SECRET`).WillReturnError(&pq.Error{
+			Code:    "42710",
+			Message: `catalog item 'secret' already exists`,
+			Detail:  "detail",
+			Hint:    "hint",
+		})
+
+		diags := ExecResource(context.TODO(), testMeta(db), `CREATE SECRET schema.secret AS 'password';`)
+		r.True(diags.HasError())
+		r.Equal(`error executing statement: pq: catalog item 'secret' already exists`, diags[0].Summary)
+		r.Equal("Statement: CREATE SECRET schema.secret AS 'password';\nCode: 42710\nDetail: detail\nHint: hint", diags[0].Detail)
+	})
+}
+
+func TestExecRedactedResourceError(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.ExpectExec(`CREATE SECRET`).WillReturnError(&pq.Error{
+			Code:    "42710",
+			Message: `catalog item 'secret' already exists`,
+			Detail:  "detail",
+			Hint:    "hint",
+		})
+
+		diags := execRedactedResource(context.TODO(), testMeta(db), `CREATE SECRET schema.secret AS 'password';`, `CREATE SECRET schema.secret AS ********;`)
+		r.True(diags.HasError())
+		r.Equal(`error executing statement: pq: catalog item 'secret' already exists`, diags[0].Summary)
+		r.Equal("Statement: CREATE SECRET schema.secret AS ********;\nCode: 42710\nDetail: detail\nHint: hint", diags[0].Detail)
+	})
+}
+
+func TestQuoteIdentifier(t *testing.T) {
+	r := require.New(t)
+	r.Equal(`"name"`, quoteIdentifier("name"))
+	r.Equal(`"MixedCase"`, quoteIdentifier("MixedCase"))
+	r.Equal(`"with.dot-and-hyphen"`, quoteIdentifier("with.dot-and-hyphen"))
+	r.Equal(`"with""quote"`, quoteIdentifier(`with"quote`))
+}
+
+func TestQualifiedName(t *testing.T) {
+	r := require.New(t)
+	r.Equal(`"database"."Schema"."my-table"`, qualifiedName("database", "Schema", "my-table"))
+	r.Equal(`"schema"."Secret"`, quoteReference("schema.Secret"))
+}
+
+func TestQuoteString(t *testing.T) {
+	r := require.New(t)
+	r.Equal(`'value'`, quoteString("value"))
+	r.Equal(`'it''s'`, quoteString("it's"))
+}
+
+func TestWaitForReady(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.MatchExpectationsInOrder(true)
+		mock.ExpectQuery(`SELECT status`).WillReturnRows(sqlmock.NewRows([]string{"status", "error"}).AddRow("starting", nil))
+		mock.ExpectQuery(`SELECT status`).WillReturnRows(sqlmock.NewRows([]string{"status", "error"}).AddRow("running", nil))
+
+		err := waitForReady(context.TODO(), testMeta(db), time.Minute, "source s", "running", `SELECT status, error FROM statuses WHERE id = $1;`, "u1")
+		r.NoError(err)
+	})
+}
+
+func TestWaitForReadyFailed(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(`SELECT status`).WillReturnRows(sqlmock.NewRows([]string{"status", "error"}).AddRow("failed", "topic does not exist"))
+
+		err := waitForReady(context.TODO(), testMeta(db), time.Minute, "source s", "running", `SELECT status, error FROM statuses WHERE id = $1;`, "u1")
+		r.ErrorContains(err, "source s failed: topic does not exist")
+	})
+}
+
+func TestExecResourceCancelled(t *testing.T) {
+	r := require.New(t)
+
+	WithMockDb(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		mock.ExpectExec(`DROP DATABASE`).WillDelayFor(time.Minute).WillReturnResult(sqlmock.NewResult(1, 1))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		diags := ExecResource(ctx, testMeta(db), `DROP DATABASE "database";`)
+		r.True(diags.HasError())
+		r.Contains(diags[0].Summary, "canceling query")
+	})
+}
+
+func TestSuppressQualifiedNameDiff(t *testing.T) {
+	r := require.New(t)
+	r.True(suppressQualifiedNameDiff("password", "materialize.public.pass", "pass", nil))
+	r.True(suppressQualifiedNameDiff("password", "materialize.public.pass", "public.pass", nil))
+	r.True(suppressQualifiedNameDiff("password", "public.pass", "materialize.public.pass", nil))
+	r.False(suppressQualifiedNameDiff("password", "materialize.public.pass", "other.pass", nil))
+	r.False(suppressQualifiedNameDiff("password", "materialize.public.pass", "", nil))
+	r.False(suppressQualifiedNameDiff("password", "materialize.public.pass", "password", nil))
+}
+
+func TestSuppressDurationDiff(t *testing.T) {
+	r := require.New(t)
+	r.True(suppressDurationDiff("introspection_interval", "1s", "1000ms", nil))
+	r.False(suppressDurationDiff("introspection_interval", "1s", "2s", nil))
+	r.False(suppressDurationDiff("introspection_interval", "1s", "", nil))
+}
+
+func TestNormalizeStatement(t *testing.T) {
+	r := require.New(t)
+	r.Equal(
+		normalizeStatement(`SELECT "id", "name" FROM "materialize"."public"."table" WHERE "name" = 'Ab  C';`, "materialize", "public"),
+		normalizeStatement("select id,name\n  from table\n where name = 'Ab  C'", "materialize", "public"),
+	)
+	r.Equal(
+		normalizeStatement(`SELECT count(*) FROM "materialize"."other"."table"`, "materialize", "public"),
+		normalizeStatement(`SELECT count( * ) FROM other.table`, "materialize", "public"),
+	)
+	r.NotEqual(
+		normalizeStatement(`SELECT "id" FROM "materialize"."public"."table" WHERE "name" = 'ab'`, "materialize", "public"),
+		normalizeStatement(`SELECT id FROM table WHERE name = 'AB'`, "materialize", "public"),
+	)
+	r.NotEqual(
+		normalizeStatement(`SELECT "id" FROM "materialize"."public"."table"`, "materialize", "public"),
+		normalizeStatement(`SELECT id FROM other_table`, "materialize", "public"),
+	)
+}
+
+func TestCreateSqlValue(t *testing.T) {
+	r := require.New(t)
+	q := `CREATE SINK "materialize"."public"."FROM sink" IN CLUSTER "c" FROM "materialize"."public"."it""em" INTO KAFKA CONNECTION "materialize"."public"."kafka" (TOPIC = 'top''ic') FORMAT JSON ENVELOPE DEBEZIUM`
+	r.Equal(`materialize.public.it"em`, createSqlValue(q, "FROM"))
+	r.Equal(`top'ic`, createSqlValue(q, "TOPIC"))
+	r.Equal("JSON", createSqlFormat(q))
+	r.Equal("", createSqlValue(q, "USING CONFLUENT SCHEMA REGISTRY CONNECTION"))
+
+	q = `CREATE SOURCE "materialize"."public"."s" IN CLUSTER "c" FROM KAFKA CONNECTION "materialize"."public"."kafka" (TOPIC = 'events') FORMAT AVRO USING CONFLUENT SCHEMA REGISTRY CONNECTION "materialize"."public"."csr.conn" SEED VALUE SCHEMA '{"FORMAT JSON"}' ENVELOPE UPSERT`
+	r.Equal("AVRO", createSqlFormat(q))
+	r.Equal("materialize.public.csr.conn", createSqlValue(q, "USING CONFLUENT SCHEMA REGISTRY CONNECTION"))
+}
+
+func TestDefaultDatabaseName(t *testing.T) {
+	r := require.New(t)
+	meta := &ProviderMeta{Database: "analytics"}
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{"name": "secret", "value": "'password'"})
+	diff, err := Secret().Diff(context.TODO(), nil, config, meta)
+	r.NoError(err)
+	r.Equal("analytics", diff.Attributes["database_name"].New)
+
+	config = terraform.NewResourceConfigRaw(map[string]interface{}{"name": "secret", "value": "'password'", "database_name": "other"})
+	diff, err = Secret().Diff(context.TODO(), nil, config, meta)
+	r.NoError(err)
+	r.Equal("other", diff.Attributes["database_name"].New)
+}
+
+func TestSplitList(t *testing.T) {
+	r := require.New(t)
+	r.Equal([]string{`'use1-az1'`, `'use1-az2'`}, splitList(`('use1-az1', 'use1-az2')`))
+	r.Equal([]string{`'a, b'`, `"schema"."table" AS "t"`}, splitList(`('a, b', "schema"."table" AS "t")`))
+	r.Equal([]string{`f(1, 2)`}, splitList(`(f(1, 2))`))
+	r.Nil(splitList(`()`))
+	r.Nil(splitList(`'value'`))
+}
+
+// Objects cannot be moved between schemas, so changing the schema recreates them
+func TestSchemaNameForceNew(t *testing.T) {
+	for name, r := range map[string]*schema.Resource{
+		"connection_aws_privatelink":           ConnectionAwsPrivateLink(),
+		"connection_confluent_schema_registry": ConnectionConfluentSchemaRegistry(),
+		"connection_kafka":                     ConnectionKafka(),
+		"connection_postgres":                  ConnectionPostgres(),
+		"connection_ssh_tunnel":                ConnectionSshTunnel(),
+		"index":                                Index(),
+		"materialized_view":                    MaterializedView(),
+		"secret":                               Secret(),
+		"sink":                                 Sink(),
+		"source":                               Source(),
+		"table":                                Table(),
+		"view":                                 View(),
+	} {
+		require.True(t, r.Schema["schema_name"].ForceNew, "%s schema_name is not ForceNew", name)
+	}
+}